@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+type AbandonedCartConfig struct {
+	IdleFor       time.Duration
+	CheckInterval time.Duration
+	BatchSize     int
+}
+
+// GetAbandonedCartConfig reads how long a cart must stay idle to be abandoned and how often the worker runs.
+func GetAbandonedCartConfig() AbandonedCartConfig {
+	idleFor, err := time.ParseDuration(os.Getenv("ABANDONED_CART_IDLE"))
+	if err != nil || idleFor <= 0 {
+		idleFor = 24 * time.Hour
+	}
+
+	interval, err := time.ParseDuration(os.Getenv("ABANDONED_CART_CHECK_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = 10 * time.Minute
+	}
+
+	return AbandonedCartConfig{
+		IdleFor:       idleFor,
+		CheckInterval: interval,
+		BatchSize:     100,
+	}
+}
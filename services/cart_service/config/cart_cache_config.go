@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type CartCacheConfig struct {
+	Enabled bool
+	TTL     time.Duration
+}
+
+// GetCartCacheConfig reads the cart cache settings. CART_CACHE_ENABLED=false makes every read go to MySQL.
+func GetCartCacheConfig() CartCacheConfig {
+	enabled, err := strconv.ParseBool(os.Getenv("CART_CACHE_ENABLED"))
+	if err != nil {
+		enabled = true
+	}
+
+	ttl, err := time.ParseDuration(os.Getenv("CART_CACHE_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+
+	return CartCacheConfig{
+		Enabled: enabled,
+		TTL:     ttl,
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// GetCartShareTTL is how long a shared cart can be opened, CART_SHARE_TTL defaults to 7 days.
+func GetCartShareTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("CART_SHARE_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 7 * 24 * time.Hour
+	}
+	return ttl
+}
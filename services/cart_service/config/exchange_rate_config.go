@@ -0,0 +1,49 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/fx"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+)
+
+// GetExchangeRateProvider picks the provider with FX_PROVIDER.
+// "http" calls FX_API_URL and caches its rates for FX_CACHE_TTL, anything else reads the FX_RATES_FILE JSON file
+// and falls back to the rates bundled with the service.
+func GetExchangeRateProvider() output.ExchangeRateProvider {
+	if os.Getenv("FX_PROVIDER") == "http" {
+		baseURL := os.Getenv("FX_API_URL")
+		if baseURL == "" {
+			log.Fatal("FX_API_URL is required when FX_PROVIDER is http")
+		}
+
+		timeout, err := time.ParseDuration(os.Getenv("FX_API_TIMEOUT"))
+		if err != nil || timeout <= 0 {
+			timeout = 5 * time.Second
+		}
+
+		ttl, err := time.ParseDuration(os.Getenv("FX_CACHE_TTL"))
+		if err != nil || ttl <= 0 {
+			ttl = time.Hour
+		}
+
+		return fx.NewHTTPExchangeRateProvider(baseURL, timeout, ttl)
+	}
+
+	rates := fx.DefaultRates
+	if path := os.Getenv("FX_RATES_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatal("Failed to read exchange rates file:", err)
+		}
+		rates = data
+	}
+
+	provider, err := fx.NewStaticExchangeRateProvider(rates)
+	if err != nil {
+		log.Fatal("Failed to load exchange rates:", err)
+	}
+	return provider
+}
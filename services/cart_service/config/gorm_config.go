@@ -41,10 +41,24 @@ func GORMConfig() *gorm.DB {
 	if err := db.AutoMigrate(
 		&models.CartModel{},
 		&models.CartItemModel{},
+		&models.SavedItemModel{},
+		&models.CheckoutModel{},
+		&models.CheckoutItemModel{},
+		&models.OutboxEventModel{},
+		&models.CartEventModel{},
+		&models.CartSnapshotModel{},
+		&models.CartSnapshotItemModel{},
+		&models.PromotionModel{},
+		&models.PromotionRedemptionModel{},
+		&models.ProcessedUserEventModel{},
 	); err != nil {
 		log.Fatal("Failed to migrate database schema:", err)
 	}
 
+	if err := runMigrations(db); err != nil {
+		log.Fatal("Failed to migrate database data:", err)
+	}
+
 	log.Println("Database connected successfully!")
 	return db
 }
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"crypto/rand"
+	"log"
+	"os"
+	"time"
+
+	carttoken "github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/cart_token"
+)
+
+// GetGuestCartSigner builds the signer for guest cart tokens.
+// Without GUEST_CART_SECRET a random secret is used, so tokens do not survive a restart.
+func GetGuestCartSigner() *carttoken.Signer {
+	secret := []byte(os.Getenv("GUEST_CART_SECRET"))
+	if len(secret) == 0 {
+		log.Println("GUEST_CART_SECRET is not set, guest cart tokens will be invalidated on restart")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal("Failed to generate guest cart secret:", err)
+		}
+	}
+
+	ttl, err := time.ParseDuration(os.Getenv("GUEST_CART_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = 7 * 24 * time.Hour
+	}
+
+	return carttoken.NewSigner(secret, ttl)
+}
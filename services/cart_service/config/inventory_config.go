@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// GetInventoryHoldTTL is how long stock stays held for a checkout whose transaction did not commit yet.
+func GetInventoryHoldTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("INVENTORY_HOLD_TTL"))
+	if err != nil || ttl <= 0 {
+		return 15 * time.Minute
+	}
+	return ttl
+}
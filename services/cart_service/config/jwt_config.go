@@ -0,0 +1,18 @@
+package config
+
+import (
+	"log"
+	"os"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/auth"
+)
+
+// GetAuthenticator builds the validator for user_service access tokens, both services must share JWT_SECRET_KEY.
+func GetAuthenticator() *auth.Authenticator {
+	secret := os.Getenv("JWT_SECRET_KEY")
+	if secret == "" {
+		log.Fatal("JWT_SECRET_KEY is not defined in the environment variables")
+	}
+
+	return auth.NewAuthenticator([]byte(secret))
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"gorm.io/gorm"
+)
+
+// floatColumnMigration moves a legacy float64 price column into its minor unit BIGINT replacement.
+type floatColumnMigration struct {
+	table     string
+	oldColumn string
+	newColumn string
+}
+
+var moneyColumnMigrations = []floatColumnMigration{
+	{"cart_items", "unit_price", "unit_price_amount"},
+	{"cart_items", "discount", "discount_amount"},
+	{"checkouts", "sub_total", "sub_total_amount"},
+	{"checkouts", "total_discount", "total_discount_amount"},
+	{"checkouts", "total", "total_amount"},
+	{"checkout_items", "unit_price", "unit_price_amount"},
+	{"checkout_items", "discount", "discount_amount"},
+}
+
+// runMigrations applies the data migrations AutoMigrate can't express.
+// Each step checks the schema first, so running it again is a no-op.
+func runMigrations(db *gorm.DB) error {
+	factor := 1
+	for i := 0; i < money.MinorUnits(money.DefaultCurrency); i++ {
+		factor *= 10
+	}
+
+	for _, migration := range moneyColumnMigrations {
+		if !db.Migrator().HasColumn(migration.table, migration.oldColumn) {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			update := fmt.Sprintf("UPDATE `%s` SET `%s` = ROUND(`%s` * ?)", migration.table, migration.newColumn, migration.oldColumn)
+			if err := tx.Exec(update, factor).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(migration.table, migration.oldColumn)
+		})
+		if err != nil {
+			return fmt.Errorf("migrating %s.%s: %w", migration.table, migration.oldColumn, err)
+		}
+
+		log.Printf("Migrated %s.%s to %s", migration.table, migration.oldColumn, migration.newColumn)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// GetOutboxMaxAttempts is how many times the relay tries to publish an outbox event before it marks it as failed.
+func GetOutboxMaxAttempts() int {
+	maxAttempts, err := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts <= 0 {
+		return 10
+	}
+	return maxAttempts
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+)
+
+func GetProductServiceConfig() facadeService.ProductServiceConfig {
+	baseURL := os.Getenv("PRODUCT_SERVICE_URL")
+	if baseURL == "" {
+		baseURL = "http://product-service:3000/v1/api"
+	}
+
+	timeout, err := time.ParseDuration(os.Getenv("PRODUCT_SERVICE_TIMEOUT"))
+	if err != nil {
+		timeout = 3 * time.Second
+	}
+
+	maxRetries, err := strconv.Atoi(os.Getenv("PRODUCT_SERVICE_MAX_RETRIES"))
+	if err != nil {
+		maxRetries = 2
+	}
+
+	return facadeService.ProductServiceConfig{
+		BaseURL:      baseURL,
+		Timeout:      timeout,
+		MaxRetries:   maxRetries,
+		RetryBackoff: 200 * time.Millisecond,
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type UserEventsConfig struct {
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// GetUserEventsConfig reads how many times a user event is tried before it goes to the dead letter queue.
+func GetUserEventsConfig() UserEventsConfig {
+	maxAttempts, err := strconv.Atoi(os.Getenv("USER_EVENTS_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	retryBackoff, err := time.ParseDuration(os.Getenv("USER_EVENTS_RETRY_BACKOFF"))
+	if err != nil || retryBackoff <= 0 {
+		retryBackoff = 2 * time.Second
+	}
+
+	return UserEventsConfig{
+		MaxAttempts:  maxAttempts,
+		RetryBackoff: retryBackoff,
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+)
+
+func GetUserServiceConfig() facadeService.UserServiceConfig {
+	baseURL := os.Getenv("USER_SERVICE_URL")
+	if baseURL == "" {
+		baseURL = "http://user-service:3000/v1/api"
+	}
+
+	timeout, err := time.ParseDuration(os.Getenv("USER_SERVICE_TIMEOUT"))
+	if err != nil {
+		timeout = 3 * time.Second
+	}
+
+	maxRetries, err := strconv.Atoi(os.Getenv("USER_SERVICE_MAX_RETRIES"))
+	if err != nil {
+		maxRetries = 2
+	}
+
+	return facadeService.UserServiceConfig{
+		BaseURL:      baseURL,
+		ServiceToken: os.Getenv("INTERNAL_SERVICE_TOKEN"),
+		Timeout:      timeout,
+		MaxRetries:   maxRetries,
+		RetryBackoff: 200 * time.Millisecond,
+	}
+}
@@ -0,0 +1,145 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/rabbitmq"
+	"github.com/streadway/amqp"
+)
+
+// UserEventsQueue receives the user lifecycle events of user_service, see dtos.UserEventDTO.
+// Its dead letter queue is rabbitmq.DeadLetterQueue(UserEventsQueue).
+const UserEventsQueue = "user_events_queue"
+
+const reconnectDelay = 5 * time.Second
+
+// UserEventConsumer applies the user events to the carts. Messages are acknowledged once applied.
+// Malformed events and events still failing after maxAttempts are rejected to the dead letter queue.
+type UserEventConsumer struct {
+	conn         *amqp.Connection
+	useCase      input.UserEventUseCase
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+func NewUserEventConsumer(conn *amqp.Connection, useCase input.UserEventUseCase, maxAttempts int, retryBackoff time.Duration) *UserEventConsumer {
+	return &UserEventConsumer{
+		conn:         conn,
+		useCase:      useCase,
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+	}
+}
+
+// Start consumes until ctx is done, opening a new channel when the current one fails.
+func (c *UserEventConsumer) Start(ctx context.Context) {
+	for {
+		if err := c.consume(ctx); err != nil {
+			log.Printf("User events: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (c *UserEventConsumer) consume(ctx context.Context) error {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := rabbitmq.DeclareQueueWithDeadLetter(ch, UserEventsQueue); err != nil {
+		return err
+	}
+
+	// One message at a time keeps the events of a user in order
+	if err := ch.Qos(1, 0, false); err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(
+		UserEventsQueue,
+		"",
+		false, // Auto-Acknowledge
+		false, // Exclusive
+		false, // No-Local
+		false, // No-Wait
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Println("User events: listening on", UserEventsQueue)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return errors.New("channel closed")
+			}
+			c.handle(ctx, msg)
+		}
+	}
+}
+
+func (c *UserEventConsumer) handle(ctx context.Context, msg amqp.Delivery) {
+	var event dtos.UserEventDTO
+	if err := json.Unmarshal(msg.Body, &event); err != nil {
+		c.deadLetter(msg, err)
+		return
+	}
+
+	if err := c.apply(ctx, event); err != nil {
+		// Stopping is not a failure of the event, leave it for the next consumer
+		if ctx.Err() != nil {
+			msg.Nack(false, true)
+			return
+		}
+		c.deadLetter(msg, err)
+		return
+	}
+
+	if err := msg.Ack(false); err != nil {
+		log.Printf("User events: acknowledging %s: %v", event.EventID, err)
+	}
+}
+
+// apply retries the errors that may go away, like a lost database connection, with a growing backoff.
+func (c *UserEventConsumer) apply(ctx context.Context, event dtos.UserEventDTO) error {
+	var err error
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		err = c.useCase.HandleUserEvent(ctx, event)
+		if err == nil || errors.Is(err, domain.ErrInvalidUserEvent) || attempt == c.maxAttempts {
+			return err
+		}
+
+		log.Printf("User events: %s %s failed (attempt %d/%d): %v", event.EventType, event.EventID, attempt, c.maxAttempts, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * c.retryBackoff):
+		}
+	}
+	return err
+}
+
+func (c *UserEventConsumer) deadLetter(msg amqp.Delivery, reason error) {
+	log.Printf("User events: moving message %s to %s: %v", msg.MessageId, rabbitmq.DeadLetterQueue(UserEventsQueue), reason)
+	if err := msg.Nack(false, false); err != nil {
+		log.Printf("User events: rejecting message: %v", err)
+	}
+}
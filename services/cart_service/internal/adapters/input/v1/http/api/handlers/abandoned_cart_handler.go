@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AbandonedCartHandler struct {
+	abandonedCartUseCase input.AbandonedCartUseCase
+}
+
+func NewAbandonedCartHandler(abandonedCartUseCase input.AbandonedCartUseCase) *AbandonedCartHandler {
+	return &AbandonedCartHandler{abandonedCartUseCase: abandonedCartUseCase}
+}
+
+// GetAbandonedCarts is a dry run of the abandoned cart worker: it lists the candidates without notifying them.
+func (h *AbandonedCartHandler) GetAbandonedCarts(c *fiber.Ctx) error {
+	carts, err := h.abandonedCartUseCase.GetAbandonedCarts(context.Background())
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(carts)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/repository"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CartCacheHandler struct {
+	cartCache *repository.CachedCartRepository
+}
+
+// NewCartCacheHandler takes a nil cache when caching is disabled.
+func NewCartCacheHandler(cartCache *repository.CachedCartRepository) *CartCacheHandler {
+	return &CartCacheHandler{cartCache: cartCache}
+}
+
+func (h *CartCacheHandler) GetStats(c *fiber.Ctx) error {
+	if h.cartCache == nil {
+		return c.Status(200).JSON(fiber.Map{"enabled": false})
+	}
+
+	return c.Status(200).JSON(fiber.Map{"enabled": true, "stats": h.cartCache.Stats()})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// The cart ETag is its version. Clients send it back in If-Match to make sure they change the cart they saw.
+
+func cartETag(version int) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// ifMatchContext returns a context carrying the version of the If-Match header.
+// Without the header, or with "*", the change is applied to whatever the current version is.
+func ifMatchContext(c *fiber.Ctx) (context.Context, error) {
+	ctx := context.Background()
+
+	ifMatch := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if ifMatch == "" || ifMatch == "*" {
+		return ctx, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), "\""))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid If-Match header")
+	}
+
+	return input.WithExpectedVersion(ctx, version), nil
+}
+
+func sendCart(c *fiber.Ctx, cart *dtos.CartDTO) error {
+	c.Set(fiber.HeaderETag, cartETag(cart.Version))
+	return c.Status(200).JSON(cart)
+}
+
+// sendConflict answers 409 with the current cart so the client can re-apply its change and retry.
+func sendConflict(c *fiber.Ctx, cartUseCase input.CartUseCase, userId uuid.UUID, err error) error {
+	cart, getErr := cartUseCase.GetCartByUserId(context.Background(), userId)
+	if getErr != nil {
+		return sendError(c, err)
+	}
+
+	c.Set(fiber.HeaderETag, cartETag(cart.Version))
+	return sendProblem(c, err, fiber.Map{"cart": cart})
+}
+
+// sendCartChanged answers 409 with the lines that changed and the cart with its refreshed prices.
+// The client shows the changes and buys again, which acknowledges them.
+func sendCartChanged(c *fiber.Ctx, cartUseCase input.CartUseCase, userId uuid.UUID, changedErr *domain.CartChangedError) error {
+	var mapper mappers.CartMapper
+	extensions := fiber.Map{"changes": mapper.ChangesToDTOs(changedErr.Changes)}
+
+	if cart, err := cartUseCase.GetCartByUserId(context.Background(), userId); err == nil {
+		c.Set(fiber.HeaderETag, cartETag(cart.Version))
+		extensions["cart"] = cart
+	}
+
+	return sendProblem(c, changedErr, extensions)
+}
+
+func isConflict(err error) bool {
+	return errors.Is(err, domain.ErrConcurrentModification)
+}
@@ -21,12 +21,12 @@ func (h *CartHandler) InitCart(c *fiber.Ctx) error {
 	userIdStr := c.Params("userId")
 	userId, err := uuid.Parse(userIdStr)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendBadRequest(c, "Invalid user id")
 	}
 
 	err = h.cartUseCase.CreateCart(context.Background(), userId)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
 	return c.Status(200).JSON("Cart Successfully Init for New User")
@@ -36,42 +36,57 @@ func (h *CartHandler) GetCartByUserId(c *fiber.Ctx) error {
 	userIdStr := c.Params("userId")
 	userId, err := uuid.Parse(userIdStr)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendBadRequest(c, "Invalid user id")
 	}
 
 	cart, err := h.cartUseCase.GetCartByUserId(context.Background(), userId)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
-	return c.Status(200).JSON(cart)
+	return sendCart(c, cart)
 }
 
 func (h *CartHandler) GetCartById(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendBadRequest(c, "Invalid user id")
 	}
 
 	cart, err := h.cartUseCase.GetCartById(context.Background(), id)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
-	return c.Status(200).JSON(cart)
+	return sendCart(c, cart)
+}
+
+func (h *CartHandler) ValidateCart(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return sendBadRequest(c, "Invalid cart id")
+	}
+
+	validation, err := h.cartUseCase.ValidateCart(context.Background(), id)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(validation)
 }
 
 func (h *CartHandler) DeleteCart(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendBadRequest(c, "Invalid user id")
 	}
 
 	err = h.cartUseCase.DeleteCart(context.Background(), id)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
 	return c.Status(200).JSON("Cart Successfully Deleted")
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CartHistoryHandler struct {
+	cartHistoryUseCase input.CartHistoryUseCase
+}
+
+func NewCartHistoryHandler(cartHistoryUseCase input.CartHistoryUseCase) *CartHistoryHandler {
+	return &CartHistoryHandler{cartHistoryUseCase: cartHistoryUseCase}
+}
+
+func (h *CartHistoryHandler) GetTimeline(c *fiber.Ctx) error {
+	cartId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return sendBadRequest(c, "Invalid cart id")
+	}
+
+	timeline, err := h.cartHistoryUseCase.GetTimeline(context.Background(), cartId)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(timeline)
+}
+
+// GetCartAsOf expects the time as an RFC 3339 "at" query parameter, e.g. ?at=2024-05-01T10:00:00Z.
+func (h *CartHistoryHandler) GetCartAsOf(c *fiber.Ctx) error {
+	cartId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return sendBadRequest(c, "Invalid cart id")
+	}
+
+	at, err := time.Parse(time.RFC3339, c.Query("at"))
+	if err != nil {
+		return sendBadRequest(c, "Invalid at, expected an RFC 3339 time")
+	}
+
+	cart, err := h.cartHistoryUseCase.GetCartAsOf(context.Background(), cartId, at)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(cart)
+}
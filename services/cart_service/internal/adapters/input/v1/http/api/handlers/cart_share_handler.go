@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CartShareHandler struct {
+	cartShareUseCase input.CartShareUseCase
+	cartUseCase      input.CartUseCase
+}
+
+func NewCartShareHandler(cartShareUseCase input.CartShareUseCase, cartUseCase input.CartUseCase) *CartShareHandler {
+	return &CartShareHandler{cartShareUseCase: cartShareUseCase, cartUseCase: cartUseCase}
+}
+
+func (h *CartShareHandler) ShareCart(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	share, err := h.cartShareUseCase.ShareCart(context.Background(), userId)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(201).JSON(share)
+}
+
+// GetSharedCart needs no login, the token is what grants access to the snapshot.
+func (h *CartShareHandler) GetSharedCart(c *fiber.Ctx) error {
+	snapshot, err := h.cartShareUseCase.GetSharedCart(context.Background(), c.Params("token"))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(snapshot)
+}
+
+func (h *CartShareHandler) ImportSharedCart(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	ctx, err := ifMatchContext(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	cart, err := h.cartShareUseCase.ImportSharedCart(ctx, c.Params("token"), userId)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	return sendCart(c, cart)
+}
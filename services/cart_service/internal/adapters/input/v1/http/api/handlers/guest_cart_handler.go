@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/auth"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const CartTokenHeader = "X-Cart-Token"
+
+type GuestCartHandler struct {
+	guestCartUseCase input.GuestCartUseCase
+}
+
+func NewGuestCartHandler(guestCartUseCase input.GuestCartUseCase) *GuestCartHandler {
+	return &GuestCartHandler{guestCartUseCase: guestCartUseCase}
+}
+
+func (h *GuestCartHandler) CreateCart(c *fiber.Ctx) error {
+	guestCart, err := h.guestCartUseCase.CreateCart(context.Background())
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(201).JSON(guestCart)
+}
+
+func (h *GuestCartHandler) GetCart(c *fiber.Ctx) error {
+	cart, err := h.guestCartUseCase.GetCart(context.Background(), c.Get(CartTokenHeader))
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(cart)
+}
+
+func (h *GuestCartHandler) AddItems(c *fiber.Ctx) error {
+	var insertDTO []dtos.CartItemInserDTO
+	if err := c.BodyParser(&insertDTO); err != nil {
+		return sendBadRequest(c, "Invalid items ids")
+	}
+
+	cart, err := h.guestCartUseCase.AddItems(context.Background(), c.Get(CartTokenHeader), insertDTO)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(cart)
+}
+
+func (h *GuestCartHandler) RemoveItems(c *fiber.Ctx) error {
+	var itemsIds []uuid.UUID
+	if err := c.BodyParser(&itemsIds); err != nil {
+		return sendBadRequest(c, "Invalid items ids")
+	}
+
+	cart, err := h.guestCartUseCase.RemoveItems(context.Background(), c.Get(CartTokenHeader), itemsIds)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(cart)
+}
+
+// MergeCarts is called by the storefront right after login with the guest token it was holding.
+func (h *GuestCartHandler) MergeCarts(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	cart, err := h.guestCartUseCase.MergeCarts(context.Background(), c.Get(CartTokenHeader), userId)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(cart)
+}
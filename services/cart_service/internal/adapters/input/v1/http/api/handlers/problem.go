@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/auth"
+	carttoken "github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/cart_token"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// Errors are answered with RFC 7807 problem details. The code member is the machine readable error,
+// clients should switch on it rather than on detail, which is meant for humans.
+
+const problemContentType = "application/problem+json"
+
+// Codes of the errors that are not domain errors.
+const (
+	codeInvalidRequest   = "INVALID_REQUEST"
+	codeProductNotFound  = "PRODUCT_NOT_FOUND"
+	codeCourseNotFound   = "COURSE_NOT_FOUND"
+	codeMissingToken     = "MISSING_TOKEN"
+	codeInvalidToken     = "INVALID_TOKEN"
+	codeExpiredToken     = "EXPIRED_TOKEN"
+	codeForbidden        = "FORBIDDEN"
+	codeInvalidCartToken = "INVALID_CART_TOKEN"
+	codeExpiredCartToken = "EXPIRED_CART_TOKEN"
+	codeInternalError    = "INTERNAL_ERROR"
+	internalErrorDetail  = "An unexpected error occurred"
+	// codeServiceUnavailable means a service the request depends on is down, the request can be retried later
+	codeServiceUnavailable   = "SERVICE_UNAVAILABLE"
+	serviceUnavailableDetail = "A service the request depends on is unavailable, retry later"
+)
+
+// ErrorHandler is the error handler of the app: errors returned by handlers and middlewares are answered
+// as problem details.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	return sendError(c, err)
+}
+
+// sendError answers err as problem details with the status and code it maps to.
+func sendError(c *fiber.Ctx, err error) error {
+	return sendProblem(c, err, nil)
+}
+
+// sendProblem answers err as problem details, extensions are added as extra members.
+// Errors that are not known to the service are logged and answered as a 500 without details,
+// failures of the services it depends on as a 503 without details.
+func sendProblem(c *fiber.Ctx, err error, extensions fiber.Map) error {
+	status, code := errorStatus(err)
+
+	detail := err.Error()
+	switch status {
+	case fiber.StatusInternalServerError:
+		log.Printf("%s %s: %v", c.Method(), c.Path(), err)
+		detail = internalErrorDetail
+	case fiber.StatusServiceUnavailable:
+		log.Printf("%s %s: %v", c.Method(), c.Path(), err)
+		detail = serviceUnavailableDetail
+	}
+
+	body := fiber.Map{
+		"type":     "about:blank",
+		"title":    utils.StatusMessage(status),
+		"status":   status,
+		"detail":   detail,
+		"instance": c.OriginalURL(),
+		"code":     code,
+	}
+	for name, value := range extensions {
+		body[name] = value
+	}
+
+	return c.Status(status).JSON(body, problemContentType)
+}
+
+// sendBadRequest answers 400 for a request that could not be read, detail says what was wrong.
+func sendBadRequest(c *fiber.Ctx, detail string) error {
+	return sendError(c, fiber.NewError(fiber.StatusBadRequest, detail))
+}
+
+// errorStatus maps err to an HTTP status and a code. Domain errors are mapped by kind and keep their code.
+func errorStatus(err error) (int, string) {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return kindStatus(domainErr.Kind), domainErr.Code
+	}
+
+	var fiberErr *fiber.Error
+	switch {
+	case errors.Is(err, facadeService.ErrProductNotFound):
+		return fiber.StatusNotFound, codeProductNotFound
+	case errors.Is(err, facadeService.ErrCourseNotFound):
+		return fiber.StatusNotFound, codeCourseNotFound
+	case errors.Is(err, facadeService.ErrServiceUnavailable):
+		return fiber.StatusServiceUnavailable, codeServiceUnavailable
+	case errors.Is(err, auth.ErrMissingToken):
+		return fiber.StatusUnauthorized, codeMissingToken
+	case errors.Is(err, auth.ErrInvalidToken):
+		return fiber.StatusUnauthorized, codeInvalidToken
+	case errors.Is(err, auth.ErrExpiredToken):
+		return fiber.StatusUnauthorized, codeExpiredToken
+	case errors.Is(err, auth.ErrForbidden):
+		return fiber.StatusForbidden, codeForbidden
+	case errors.Is(err, carttoken.ErrInvalidToken):
+		return fiber.StatusUnauthorized, codeInvalidCartToken
+	case errors.Is(err, carttoken.ErrExpiredToken):
+		return fiber.StatusUnauthorized, codeExpiredCartToken
+	case errors.As(err, &fiberErr):
+		if fiberErr.Code == fiber.StatusBadRequest {
+			return fiberErr.Code, codeInvalidRequest
+		}
+		return fiberErr.Code, statusCode(fiberErr.Code)
+	default:
+		return fiber.StatusInternalServerError, codeInternalError
+	}
+}
+
+func kindStatus(kind domain.ErrorKind) int {
+	switch kind {
+	case domain.KindInvalid:
+		return fiber.StatusBadRequest
+	case domain.KindNotFound:
+		return fiber.StatusNotFound
+	case domain.KindConflict, domain.KindUnavailable:
+		return fiber.StatusConflict
+	case domain.KindRuleViolation, domain.KindLimitExceeded:
+		return fiber.StatusUnprocessableEntity
+	case domain.KindExpired:
+		return fiber.StatusGone
+	case domain.KindForbidden:
+		return fiber.StatusForbidden
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// statusCode turns a status into a code, 404 gives NOT_FOUND.
+func statusCode(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(utils.StatusMessage(status), " ", "_"))
+}
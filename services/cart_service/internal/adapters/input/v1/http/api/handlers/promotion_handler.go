@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PromotionHandler struct {
+	promotionUseCase input.PromotionUseCase
+}
+
+func NewPromotionHandler(promotionUseCase input.PromotionUseCase) *PromotionHandler {
+	return &PromotionHandler{promotionUseCase: promotionUseCase}
+}
+
+func (h *PromotionHandler) CreatePromotion(c *fiber.Ctx) error {
+	var insertDTO dtos.PromotionInsertDTO
+	if err := c.BodyParser(&insertDTO); err != nil {
+		return sendBadRequest(c, "Invalid promotion data")
+	}
+
+	promotion, err := h.promotionUseCase.CreatePromotion(context.Background(), insertDTO)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(201).JSON(promotion)
+}
+
+func (h *PromotionHandler) GetAllPromotions(c *fiber.Ctx) error {
+	promotions, err := h.promotionUseCase.GetAllPromotions(context.Background())
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(promotions)
+}
+
+func (h *PromotionHandler) GetPromotionById(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return sendBadRequest(c, "Invalid promotion id")
+	}
+
+	promotion, err := h.promotionUseCase.GetPromotionById(context.Background(), id)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(promotion)
+}
+
+func (h *PromotionHandler) UpdatePromotion(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return sendBadRequest(c, "Invalid promotion id")
+	}
+
+	var insertDTO dtos.PromotionInsertDTO
+	if err := c.BodyParser(&insertDTO); err != nil {
+		return sendBadRequest(c, "Invalid promotion data")
+	}
+
+	promotion, err := h.promotionUseCase.UpdatePromotion(context.Background(), id, insertDTO)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(promotion)
+}
+
+func (h *PromotionHandler) DeletePromotion(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return sendBadRequest(c, "Invalid promotion id")
+	}
+
+	if err := h.promotionUseCase.DeletePromotion(context.Background(), id); err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON("Promotion Successfully Deleted")
+}
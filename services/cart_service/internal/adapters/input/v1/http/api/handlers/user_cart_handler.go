@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/auth"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -18,95 +22,359 @@ func NewUserCartHandler(cartUseCase input.CartUseCase) *UserCartHandler {
 
 }
 
-// TODO Import or Whatever to get User Id from JWT
 func (h *UserCartHandler) GetMyCart(c *fiber.Ctx) error {
-	userIdStr := c.Params("userId")
-	userId, err := uuid.Parse(userIdStr)
+	userId, err := auth.UserID(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendError(c, err)
 	}
 
 	cart, err := h.cartUseCase.GetCartByUserId(context.Background(), userId)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
-	return c.Status(200).JSON(cart)
+	return sendCart(c, cart)
+}
+
+func (h *UserCartHandler) ValidateMyCart(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	validation, err := h.cartUseCase.ValidateMyCart(context.Background(), userId)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(validation)
 }
 
 func (h *UserCartHandler) AddItems(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	userId, err := uuid.Parse(idStr)
+	userId, err := auth.UserID(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendError(c, err)
 	}
 
 	var insertDTO []dtos.CartItemInserDTO
 	if err := c.BodyParser(&insertDTO); err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid items ids"})
+		return sendBadRequest(c, "Invalid items ids")
 	}
 
-	cart, err := h.cartUseCase.AddItems(context.Background(), userId, insertDTO)
+	ctx, err := ifMatchContext(c)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
-	return c.Status(200).JSON(cart)
+	cart, err := h.cartUseCase.AddItems(ctx, userId, insertDTO)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	return sendCart(c, cart)
+}
+
+func (h *UserCartHandler) UpdateItemQuantity(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	itemId, err := uuid.Parse(c.Params("itemId"))
+	if err != nil {
+		return sendBadRequest(c, "Invalid item id")
+	}
+
+	var quantityDTO dtos.CartItemQuantityDTO
+	if err := c.BodyParser(&quantityDTO); err != nil {
+		return sendBadRequest(c, "Invalid quantity")
+	}
+
+	ctx, err := ifMatchContext(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	cart, err := h.cartUseCase.UpdateItemQuantity(ctx, userId, itemId, quantityDTO.Quantity)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	return sendCart(c, cart)
 }
 
 func (h *UserCartHandler) RemoveItems(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	userId, err := uuid.Parse(idStr)
+	userId, err := auth.UserID(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendError(c, err)
 	}
 
 	var itemsIds []uuid.UUID
 	if err := c.BodyParser(&itemsIds); err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid items ids"})
+		return sendBadRequest(c, "Invalid items ids")
+	}
+
+	ctx, err := ifMatchContext(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	cart, err := h.cartUseCase.RemoveItems(ctx, userId, itemsIds)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
 	}
 
-	cart, err := h.cartUseCase.RemoveItems(context.Background(), userId, itemsIds)
+	return sendCart(c, cart)
+}
+
+// ApplyOperations applies a list of add, remove and set_quantity operations, as sent by clients syncing offline edits.
+// It answers 200 with a result per operation, or 422 when the request failed without changing the cart.
+func (h *UserCartHandler) ApplyOperations(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	var request dtos.CartOperationsDTO
+	if err := c.BodyParser(&request); err != nil {
+		return sendBadRequest(c, "Invalid operations")
+	}
+
+	if len(request.Operations) == 0 || len(request.Operations) > dtos.MaxCartOperations {
+		return sendBadRequest(c, fmt.Sprintf("Between 1 and %d operations are expected", dtos.MaxCartOperations))
+	}
+
+	ctx, err := ifMatchContext(c)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
-	return c.Status(200).JSON(cart)
+	result, err := h.cartUseCase.ApplyOperations(ctx, userId, request)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	c.Set(fiber.HeaderETag, cartETag(result.Cart.Version))
+	if result.Applied == 0 && result.Failed > 0 {
+		return c.Status(422).JSON(result)
+	}
+
+	return c.Status(200).JSON(result)
 }
 
 func (h *UserCartHandler) Buy(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	userId, err := uuid.Parse(idStr)
+	userId, err := auth.UserID(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendError(c, err)
 	}
 
 	// Allow empty
 	var exludeItemsIds []*uuid.UUID
 	if err := c.BodyParser(&exludeItemsIds); err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid items ids"})
+		return sendBadRequest(c, "Invalid items ids")
 	}
 
-	err = h.cartUseCase.Buy(context.Background(), userId, exludeItemsIds)
+	ctx, err := ifMatchContext(c)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		return sendError(c, err)
 	}
 
-	return c.Status(200).JSON("Cart Operation for Buy Completed")
+	purchase, err := h.cartUseCase.Buy(ctx, userId, exludeItemsIds)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+
+		var changedErr *domain.CartChangedError
+		if errors.As(err, &changedErr) {
+			return sendCartChanged(c, h.cartUseCase, userId, changedErr)
+		}
+
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(purchase)
 }
 
-// Implement this
 func (h *UserCartHandler) BuyProduct(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	id, err := uuid.Parse(idStr)
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	var insertDTO dtos.CartItemInserDTO
+	if err := c.BodyParser(&insertDTO); err != nil {
+		return sendBadRequest(c, "Invalid product data")
+	}
+
+	purchase, err := h.cartUseCase.BuyProduct(context.Background(), userId, insertDTO)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(purchase)
+}
+
+func (h *UserCartHandler) ApplyCoupon(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	var couponDTO dtos.ApplyCouponDTO
+	if err := c.BodyParser(&couponDTO); err != nil || couponDTO.Code == "" {
+		return sendBadRequest(c, "Invalid coupon code")
+	}
+
+	ctx, err := ifMatchContext(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	cart, err := h.cartUseCase.ApplyCoupon(ctx, userId, couponDTO.Code)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	return sendCart(c, cart)
+}
+
+func (h *UserCartHandler) RemoveCoupon(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	ctx, err := ifMatchContext(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	cart, err := h.cartUseCase.RemoveCoupon(ctx, userId)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	return sendCart(c, cart)
+}
+
+func (h *UserCartHandler) SetDisplayCurrency(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	var currencyDTO dtos.DisplayCurrencyDTO
+	if err := c.BodyParser(&currencyDTO); err != nil {
+		return sendBadRequest(c, "Invalid currency")
+	}
+
+	ctx, err := ifMatchContext(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	cart, err := h.cartUseCase.SetDisplayCurrency(ctx, userId, currencyDTO.Currency)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	return sendCart(c, cart)
+}
+
+func (h *UserCartHandler) GetSavedItems(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	savedItems, err := h.cartUseCase.GetSavedItems(context.Background(), userId)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(savedItems)
+}
+
+func (h *UserCartHandler) MoveToSaved(c *fiber.Ctx) error {
+	return h.moveItem(c, h.cartUseCase.MoveToSaved)
+}
+
+func (h *UserCartHandler) MoveToCart(c *fiber.Ctx) error {
+	return h.moveItem(c, h.cartUseCase.MoveToCart)
+}
+
+func (h *UserCartHandler) RemoveSavedItem(c *fiber.Ctx) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	itemId, err := uuid.Parse(c.Params("itemId"))
+	if err != nil {
+		return sendBadRequest(c, "Invalid item id")
+	}
+
+	ctx, err := ifMatchContext(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	savedItems, err := h.cartUseCase.RemoveSavedItem(ctx, userId, itemId)
+	if err != nil {
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
+	}
+
+	return c.Status(200).JSON(savedItems)
+}
+
+func (h *UserCartHandler) moveItem(c *fiber.Ctx, move func(context.Context, uuid.UUID, uuid.UUID) (*dtos.CartDTO, error)) error {
+	userId, err := auth.UserID(c)
+	if err != nil {
+		return sendError(c, err)
+	}
+
+	itemId, err := uuid.Parse(c.Params("itemId"))
+	if err != nil {
+		return sendBadRequest(c, "Invalid item id")
+	}
+
+	ctx, err := ifMatchContext(c)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"message": "Invalid user id"})
+		return sendError(c, err)
 	}
 
-	cart, err := h.cartUseCase.GetCartById(context.Background(), id)
+	cart, err := move(ctx, userId, itemId)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"message": err.Error()})
+		if isConflict(err) {
+			return sendConflict(c, h.cartUseCase, userId, err)
+		}
+		return sendError(c, err)
 	}
 
-	return c.Status(200).JSON(cart)
+	return sendCart(c, cart)
 }
@@ -2,29 +2,89 @@ package routes
 
 import (
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/input/v1/http/api/handlers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/auth"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-func CartRoutes(app *fiber.App, cartHandler handlers.CartHandler) {
+// CartRoutes can read and change any cart, so they are reserved to admins.
+// The middleware is set per route because a "/v1/api" group middleware would also run on the user and guest routes.
+func CartRoutes(app *fiber.App, cartHandler handlers.CartHandler, authenticator *auth.Authenticator) {
 	path := app.Group("/v1/api")
+	requireAdmin := auth.RequireRole(auth.AdminRole)
 
-	path.Post("/carts/user/:userId", cartHandler.InitCart)
-	path.Get("/carts/user/:userId", cartHandler.GetCartByUserId)
-	path.Get("/carts/:id", cartHandler.GetCartById)
-	path.Delete("/carts/:id", cartHandler.DeleteCart)
+	path.Post("/carts/user/:userId", authenticator.Authenticate, requireAdmin, cartHandler.InitCart)
+	path.Get("/carts/user/:userId", authenticator.Authenticate, requireAdmin, cartHandler.GetCartByUserId)
+	path.Get("/carts/:id", authenticator.Authenticate, requireAdmin, cartHandler.GetCartById)
+	path.Get("/carts/:id/validate", authenticator.Authenticate, requireAdmin, cartHandler.ValidateCart)
+	path.Delete("/carts/:id", authenticator.Authenticate, requireAdmin, cartHandler.DeleteCart)
 
 	path.Get("/uuid", func(ctx *fiber.Ctx) error {
 		return ctx.Status(200).JSON(uuid.New())
 	})
 }
 
-func UserCartRoutes(app *fiber.App, userCartHandler handlers.UserCartHandler) {
-	path := app.Group("/v1/api/user")
+// UserCartRoutes work on the cart of the authenticated caller.
+func UserCartRoutes(app *fiber.App, userCartHandler handlers.UserCartHandler, authenticator *auth.Authenticator) {
+	path := app.Group("/v1/api/user", authenticator.Authenticate)
 
-	path.Get("/carts/:userId", userCartHandler.GetMyCart)
-	path.Post("/carts/items/:id", userCartHandler.AddItems)
-	path.Delete("/carts/items/:id", userCartHandler.RemoveItems)
-	path.Post("/carts/buy/:id", userCartHandler.Buy)
-	path.Post("/carts/buy-product/:id", userCartHandler.BuyProduct)
+	path.Get("/carts", userCartHandler.GetMyCart)
+	path.Get("/carts/validate", userCartHandler.ValidateMyCart)
+	path.Post("/carts/items", userCartHandler.AddItems)
+	path.Patch("/carts/items/:itemId", userCartHandler.UpdateItemQuantity)
+	path.Delete("/carts/items", userCartHandler.RemoveItems)
+	path.Post("/carts/operations", userCartHandler.ApplyOperations)
+	path.Get("/carts/saved", userCartHandler.GetSavedItems)
+	path.Post("/carts/saved/:itemId", userCartHandler.MoveToSaved)
+	path.Post("/carts/saved/:itemId/move-to-cart", userCartHandler.MoveToCart)
+	path.Delete("/carts/saved/:itemId", userCartHandler.RemoveSavedItem)
+	path.Post("/carts/buy", userCartHandler.Buy)
+	path.Post("/carts/buy-product", userCartHandler.BuyProduct)
+	path.Post("/carts/coupon", userCartHandler.ApplyCoupon)
+	path.Delete("/carts/coupon", userCartHandler.RemoveCoupon)
+	path.Put("/carts/display-currency", userCartHandler.SetDisplayCurrency)
+}
+
+// CartHistoryRoutes serve support requests, they are reserved to admins.
+func CartHistoryRoutes(app *fiber.App, cartHistoryHandler handlers.CartHistoryHandler, authenticator *auth.Authenticator) {
+	path := app.Group("/v1/api")
+	requireAdmin := auth.RequireRole(auth.AdminRole)
+
+	path.Get("/carts/:id/timeline", authenticator.Authenticate, requireAdmin, cartHistoryHandler.GetTimeline)
+	path.Get("/carts/:id/as-of", authenticator.Authenticate, requireAdmin, cartHistoryHandler.GetCartAsOf)
+}
+
+// PromotionRoutes manage the coupons checkouts trust, they are reserved to admins.
+func PromotionRoutes(app *fiber.App, promotionHandler handlers.PromotionHandler, authenticator *auth.Authenticator) {
+	path := app.Group("/v1/api/promotions", authenticator.Authenticate, auth.RequireRole(auth.AdminRole))
+
+	path.Post("/", promotionHandler.CreatePromotion)
+	path.Get("/", promotionHandler.GetAllPromotions)
+	path.Get("/:id", promotionHandler.GetPromotionById)
+	path.Put("/:id", promotionHandler.UpdatePromotion)
+	path.Delete("/:id", promotionHandler.DeletePromotion)
+}
+
+func GuestCartRoutes(app *fiber.App, guestCartHandler handlers.GuestCartHandler, authenticator *auth.Authenticator) {
+	path := app.Group("/v1/api/guest")
+
+	path.Post("/carts", guestCartHandler.CreateCart)
+	path.Get("/carts", guestCartHandler.GetCart)
+	path.Post("/carts/items", guestCartHandler.AddItems)
+	path.Delete("/carts/items", guestCartHandler.RemoveItems)
+
+	app.Post("/v1/api/user/carts/merge", authenticator.Authenticate, guestCartHandler.MergeCarts)
+}
+
+func CartShareRoutes(app *fiber.App, cartShareHandler handlers.CartShareHandler, authenticator *auth.Authenticator) {
+	app.Get("/v1/api/shared/carts/:token", cartShareHandler.GetSharedCart)
+	app.Post("/v1/api/user/carts/share", authenticator.Authenticate, cartShareHandler.ShareCart)
+	app.Post("/v1/api/user/carts/shared/:token/import", authenticator.Authenticate, cartShareHandler.ImportSharedCart)
+}
+
+func AdminCartRoutes(app *fiber.App, abandonedCartHandler handlers.AbandonedCartHandler, cartCacheHandler handlers.CartCacheHandler, authenticator *auth.Authenticator) {
+	path := app.Group("/v1/api/admin", authenticator.Authenticate, auth.RequireRole(auth.AdminRole))
+
+	path.Get("/carts/abandoned", abandonedCartHandler.GetAbandonedCarts)
+	path.Get("/carts/cache", cartCacheHandler.GetStats)
 }
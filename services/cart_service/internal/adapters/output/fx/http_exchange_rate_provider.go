@@ -0,0 +1,148 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+)
+
+// HTTPExchangeRateProvider fetches the rates of a base currency from GET <baseURL>/latest?base=<base>,
+// which must answer {"base": "USD", "date": "2025-01-01", "rates": {"EUR": 0.92}}; a unix "timestamp" is used over "date" when present.
+// Tables are cached for ttl. When a refresh fails the cached table is served as long as there is one.
+type HTTPExchangeRateProvider struct {
+	client  *http.Client
+	baseURL string
+	ttl     time.Duration
+
+	// mu only guards the map, each table has its own lock
+	mu     sync.Mutex
+	tables map[string]*cachedTable
+}
+
+// cachedTable is the cache of one base currency. Its lock is held while the table is fetched,
+// so a slow refresh only blocks the callers that need that base.
+type cachedTable struct {
+	mu        sync.Mutex
+	table     rateTable
+	fetchedAt time.Time
+}
+
+type latestRatesResponse struct {
+	Base      string             `json:"base"`
+	Date      string             `json:"date"`
+	Timestamp int64              `json:"timestamp"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+func NewHTTPExchangeRateProvider(baseURL string, timeout, ttl time.Duration) output.ExchangeRateProvider {
+	return &HTTPExchangeRateProvider{
+		client:  &http.Client{Timeout: timeout},
+		baseURL: strings.TrimRight(baseURL, "/"),
+		ttl:     ttl,
+		tables:  make(map[string]*cachedTable),
+	}
+}
+
+func (p *HTTPExchangeRateProvider) GetRate(ctx context.Context, base, quote string) (domain.ExchangeRate, error) {
+	base = strings.ToUpper(base)
+	if base == strings.ToUpper(quote) {
+		return domain.IdentityRate(base, time.Now()), nil
+	}
+
+	table, err := p.getTable(ctx, base)
+	if err != nil {
+		return domain.ExchangeRate{}, err
+	}
+
+	return table.rate(base, quote)
+}
+
+// getTable holds the lock of the base while fetching so concurrent misses for a base make a single request.
+func (p *HTTPExchangeRateProvider) getTable(ctx context.Context, base string) (rateTable, error) {
+	cached := p.cachedTable(base)
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	found := !cached.fetchedAt.IsZero()
+	if found && time.Since(cached.fetchedAt) < p.ttl {
+		return cached.table, nil
+	}
+
+	table, err := p.fetch(ctx, base)
+	if err != nil {
+		if found {
+			log.Printf("Exchange rates: serving cached %s rates from %s: %v", base, cached.fetchedAt.Format(time.RFC3339), err)
+			return cached.table, nil
+		}
+		return rateTable{}, err
+	}
+
+	cached.table = table
+	cached.fetchedAt = time.Now()
+	return table, nil
+}
+
+func (p *HTTPExchangeRateProvider) cachedTable(base string) *cachedTable {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cached, found := p.tables[base]
+	if !found {
+		cached = &cachedTable{}
+		p.tables[base] = cached
+	}
+	return cached
+}
+
+func (p *HTTPExchangeRateProvider) fetch(ctx context.Context, base string) (rateTable, error) {
+	endpoint := fmt.Sprintf("%s/latest?base=%s", p.baseURL, url.QueryEscape(base))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return rateTable{}, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return rateTable{}, fmt.Errorf("exchange rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rateTable{}, fmt.Errorf("exchange rates: unexpected status %d", resp.StatusCode)
+	}
+
+	var body latestRatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return rateTable{}, fmt.Errorf("exchange rates: invalid response: %w", err)
+	}
+
+	if !strings.EqualFold(body.Base, base) {
+		return rateTable{}, fmt.Errorf("exchange rates: asked for %s rates, got %s", base, body.Base)
+	}
+
+	rates := make(map[string]float64, len(body.Rates))
+	for currency, rate := range body.Rates {
+		rates[strings.ToUpper(currency)] = rate
+	}
+
+	return rateTable{Base: base, AsOf: body.asOf(), Rates: rates}, nil
+}
+
+func (r latestRatesResponse) asOf() time.Time {
+	if r.Timestamp > 0 {
+		return time.Unix(r.Timestamp, 0).UTC()
+	}
+	if date, err := time.Parse("2006-01-02", r.Date); err == nil {
+		return date
+	}
+	return time.Now().UTC()
+}
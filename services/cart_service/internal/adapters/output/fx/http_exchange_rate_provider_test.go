@@ -0,0 +1,193 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRatesServer answers GET /latest?base=<base> from rates. A base listed in blocked waits
+// for its channel to be closed before answering.
+type fakeRatesServer struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	rates    map[string]map[string]float64
+	failing  bool
+	blocked  map[string]chan struct{}
+	requests map[string]int
+}
+
+func newFakeRatesServer(rates map[string]map[string]float64) *fakeRatesServer {
+	f := &fakeRatesServer{
+		rates:    rates,
+		blocked:  make(map[string]chan struct{}),
+		requests: make(map[string]int),
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+func (f *fakeRatesServer) handle(w http.ResponseWriter, r *http.Request) {
+	base := r.URL.Query().Get("base")
+
+	f.mu.Lock()
+	f.requests[base]++
+	failing := f.failing
+	blocked := f.blocked[base]
+	rates, found := f.rates[base]
+	f.mu.Unlock()
+
+	if blocked != nil {
+		<-blocked
+	}
+	if failing || !found {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	json.NewEncoder(w).Encode(latestRatesResponse{Base: base, Date: "2025-01-02", Rates: rates})
+}
+
+func (f *fakeRatesServer) setFailing(failing bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failing = failing
+}
+
+func (f *fakeRatesServer) block(base string) chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blocked[base] = make(chan struct{})
+	return f.blocked[base]
+}
+
+func (f *fakeRatesServer) requestCount(base string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[base]
+}
+
+func testRates() map[string]map[string]float64 {
+	return map[string]map[string]float64{
+		"USD": {"EUR": 0.92, "MXN": 17.5},
+		"EUR": {"USD": 1.09},
+	}
+}
+
+func TestHTTPExchangeRateProviderGetRate(t *testing.T) {
+	server := newFakeRatesServer(testRates())
+	defer server.server.Close()
+
+	provider := NewHTTPExchangeRateProvider(server.server.URL+"/", time.Second, time.Hour)
+
+	tests := []struct {
+		name    string
+		base    string
+		quote   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "rate of the base", base: "USD", quote: "EUR", want: 0.92},
+		{name: "lower case codes", base: "usd", quote: "mxn", want: 17.5},
+		{name: "identity", base: "GBP", quote: "gbp", want: 1},
+		{name: "unknown quote", base: "USD", quote: "JPY", wantErr: true},
+		{name: "base without rates", base: "JPY", quote: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := provider.GetRate(context.Background(), tt.base, tt.quote)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got rate %+v, want an error", rate)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRate: %v", err)
+			}
+			if rate.Rate != tt.want {
+				t.Errorf("got rate %v, want %v", rate.Rate, tt.want)
+			}
+		})
+	}
+
+	if requests := server.requestCount("USD"); requests != 1 {
+		t.Errorf("got %d requests for the USD table, want 1 served from the cache afterwards", requests)
+	}
+}
+
+func TestHTTPExchangeRateProviderServesStaleTable(t *testing.T) {
+	server := newFakeRatesServer(testRates())
+	defer server.server.Close()
+
+	// Every call refreshes, the cached table is only served when the refresh fails
+	provider := NewHTTPExchangeRateProvider(server.server.URL, time.Second, time.Nanosecond)
+
+	if _, err := provider.GetRate(context.Background(), "USD", "EUR"); err != nil {
+		t.Fatalf("GetRate: %v", err)
+	}
+
+	server.setFailing(true)
+	rate, err := provider.GetRate(context.Background(), "USD", "EUR")
+	if err != nil {
+		t.Fatalf("stale table: %v", err)
+	}
+	if rate.Rate != 0.92 || !rate.AsOf.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %+v, want the cached USD rate", rate)
+	}
+	if requests := server.requestCount("USD"); requests != 2 {
+		t.Errorf("got %d requests for the USD table, want 2", requests)
+	}
+
+	if _, err := provider.GetRate(context.Background(), "EUR", "USD"); err == nil {
+		t.Errorf("got a rate for a base never fetched while the API is down")
+	}
+}
+
+func TestHTTPExchangeRateProviderLocksPerBase(t *testing.T) {
+	server := newFakeRatesServer(testRates())
+	defer server.server.Close()
+
+	provider := NewHTTPExchangeRateProvider(server.server.URL, 5*time.Second, time.Hour)
+	release := server.block("USD")
+
+	slow := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := provider.GetRate(context.Background(), "USD", "EUR")
+			slow <- err
+		}()
+	}
+
+	// A slow USD refresh does not hold back the EUR callers
+	done := make(chan error, 1)
+	go func() {
+		_, err := provider.GetRate(context.Background(), "EUR", "USD")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("EUR rate: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("EUR rate waited for the USD refresh")
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-slow; err != nil {
+			t.Fatalf("USD rate: %v", err)
+		}
+	}
+
+	if requests := server.requestCount("USD"); requests != 1 {
+		t.Errorf("got %d requests for the USD table, want concurrent misses to share 1", requests)
+	}
+}
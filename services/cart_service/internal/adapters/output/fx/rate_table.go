@@ -0,0 +1,47 @@
+package fx
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+)
+
+// rateTable lists how many units of each currency one unit of Base is worth.
+// Rates between two other currencies are crossed through Base.
+type rateTable struct {
+	Base  string             `json:"base"`
+	AsOf  time.Time          `json:"as_of"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+func (t rateTable) rate(base, quote string) (domain.ExchangeRate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if base == quote {
+		return domain.IdentityRate(base, t.AsOf), nil
+	}
+
+	baseRate, ok := t.unitsPerBase(base)
+	if !ok {
+		return domain.ExchangeRate{}, domain.ErrUnsupportedCurrency
+	}
+	quoteRate, ok := t.unitsPerBase(quote)
+	if !ok {
+		return domain.ExchangeRate{}, domain.ErrUnsupportedCurrency
+	}
+
+	return domain.ExchangeRate{
+		Base:  base,
+		Quote: quote,
+		Rate:  quoteRate / baseRate,
+		AsOf:  t.AsOf,
+	}, nil
+}
+
+func (t rateTable) unitsPerBase(currency string) (float64, bool) {
+	if currency == strings.ToUpper(t.Base) {
+		return 1, true
+	}
+	rate, ok := t.Rates[currency]
+	return rate, ok && rate > 0
+}
@@ -0,0 +1,46 @@
+package fx
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+)
+
+// DefaultRates is used when no rates file is configured. It is only meant for development.
+//
+//go:embed default_exchange_rates.json
+var DefaultRates []byte
+
+// StaticExchangeRateProvider serves the rates of a JSON file shaped like
+// {"base": "USD", "as_of": "2025-01-01T00:00:00Z", "rates": {"EUR": 0.92, "MXN": 17.1}}.
+type StaticExchangeRateProvider struct {
+	table rateTable
+}
+
+func NewStaticExchangeRateProvider(data []byte) (output.ExchangeRateProvider, error) {
+	var table rateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+
+	if table.Base == "" {
+		return nil, errors.New("exchange rates: base currency is required")
+	}
+
+	rates := make(map[string]float64, len(table.Rates))
+	for currency, rate := range table.Rates {
+		rates[strings.ToUpper(currency)] = rate
+	}
+	table.Rates = rates
+
+	return &StaticExchangeRateProvider{table: table}, nil
+}
+
+func (p *StaticExchangeRateProvider) GetRate(ctx context.Context, base, quote string) (domain.ExchangeRate, error) {
+	return p.table.rate(base, quote)
+}
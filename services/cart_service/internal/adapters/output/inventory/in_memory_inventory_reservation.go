@@ -0,0 +1,119 @@
+package inventory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+type hold struct {
+	items    map[uuid.UUID]int
+	deadline time.Time
+}
+
+// InMemoryInventoryReservation follows the Redis implementation rules in memory. Meant for tests and local runs.
+type InMemoryInventoryReservation struct {
+	mu    sync.Mutex
+	stock map[uuid.UUID]int
+	holds map[uuid.UUID]hold
+}
+
+func NewInMemoryInventoryReservation() *InMemoryInventoryReservation {
+	return &InMemoryInventoryReservation{
+		stock: make(map[uuid.UUID]int),
+		holds: make(map[uuid.UUID]hold),
+	}
+}
+
+func (r *InMemoryInventoryReservation) Reserve(ctx context.Context, reservationID uuid.UUID, items []domain.StockItem, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.holds[reservationID]; found {
+		return nil
+	}
+
+	for _, item := range items {
+		if available, tracked := r.stock[item.ProductID]; tracked && available < item.Quantity {
+			return &domain.InsufficientStockError{ProductID: item.ProductID}
+		}
+	}
+
+	held := make(map[uuid.UUID]int)
+	for _, item := range items {
+		if _, tracked := r.stock[item.ProductID]; tracked {
+			r.stock[item.ProductID] -= item.Quantity
+			held[item.ProductID] = item.Quantity
+		}
+	}
+
+	r.holds[reservationID] = hold{items: held, deadline: time.Now().Add(ttl)}
+	return nil
+}
+
+func (r *InMemoryInventoryReservation) Confirm(ctx context.Context, reservationID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.holds[reservationID]; !found {
+		return domain.ErrReservationNotFound
+	}
+
+	delete(r.holds, reservationID)
+	return nil
+}
+
+func (r *InMemoryInventoryReservation) Release(ctx context.Context, reservationID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.release(reservationID)
+	return nil
+}
+
+func (r *InMemoryInventoryReservation) ReleaseExpired(ctx context.Context, now time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	released := 0
+	for id, hold := range r.holds {
+		if !hold.deadline.After(now) {
+			r.release(id)
+			released++
+		}
+	}
+	return released, nil
+}
+
+func (r *InMemoryInventoryReservation) SeedStock(ctx context.Context, productID uuid.UUID, quantity int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, tracked := r.stock[productID]; !tracked {
+		r.stock[productID] = quantity
+	}
+	return nil
+}
+
+// Stock returns the available units of a product and whether it is tracked.
+func (r *InMemoryInventoryReservation) Stock(productID uuid.UUID) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	quantity, tracked := r.stock[productID]
+	return quantity, tracked
+}
+
+func (r *InMemoryInventoryReservation) release(reservationID uuid.UUID) {
+	hold, found := r.holds[reservationID]
+	if !found {
+		return
+	}
+
+	for productID, quantity := range hold.items {
+		r.stock[productID] += quantity
+	}
+	delete(r.holds, reservationID)
+}
@@ -0,0 +1,149 @@
+package inventory
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// Keys:
+//
+//	inventory:stock:{product id}   available units, products without this key are not tracked
+//	inventory:hold:{reservation}   hash stock key -> held units
+//	inventory:holds                sorted set of reservation ids scored by their deadline (unix ms)
+const (
+	stockKeyPrefix = "inventory:stock:"
+	holdKeyPrefix  = "inventory:hold:"
+	holdsKey       = "inventory:holds"
+)
+
+// KEYS: hold key, holds key, stock keys...  ARGV: reservation id, deadline, then one quantity per stock key.
+// Returns 0 on success or the 1-based index of the first product without enough stock.
+// A reservation already in the holds set is not taken again. The hold hash is not checked, a reservation
+// of untracked products only has its holds set member.
+var reserveScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[2], ARGV[1]) then
+	return 0
+end
+for i = 3, #KEYS do
+	local stock = redis.call('GET', KEYS[i])
+	if stock and tonumber(stock) < tonumber(ARGV[i]) then
+		return i - 2
+	end
+end
+for i = 3, #KEYS do
+	if redis.call('EXISTS', KEYS[i]) == 1 then
+		redis.call('DECRBY', KEYS[i], ARGV[i])
+		redis.call('HSET', KEYS[1], KEYS[i], ARGV[i])
+	end
+end
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[1])
+return 0
+`)
+
+// KEYS: hold key, holds key  ARGV: reservation id
+var confirmScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[2], ARGV[1]) == false then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+return 1
+`)
+
+// KEYS: holds key  ARGV: hold key prefix, reservation id
+var releaseScript = redis.NewScript(`
+local holdKey = ARGV[1] .. ARGV[2]
+local held = redis.call('HGETALL', holdKey)
+for i = 1, #held, 2 do
+	redis.call('INCRBY', held[i], held[i + 1])
+end
+redis.call('DEL', holdKey)
+return redis.call('ZREM', KEYS[1], ARGV[2])
+`)
+
+// KEYS: holds key  ARGV: hold key prefix, now (unix ms), batch size
+var releaseExpiredScript = redis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[2], 'LIMIT', 0, tonumber(ARGV[3]))
+for _, id in ipairs(expired) do
+	local holdKey = ARGV[1] .. id
+	local held = redis.call('HGETALL', holdKey)
+	for i = 1, #held, 2 do
+		redis.call('INCRBY', held[i], held[i + 1])
+	end
+	redis.call('DEL', holdKey)
+	redis.call('ZREM', KEYS[1], id)
+end
+return #expired
+`)
+
+const releaseExpiredBatch = 100
+
+// RedisInventoryReservation keeps stock levels and holds in Redis. Every operation is one Lua script,
+// so concurrent checkouts cannot both take the last unit. The scripts build hold keys at run time,
+// which requires a single Redis node.
+type RedisInventoryReservation struct {
+	client *redis.Client
+}
+
+func NewRedisInventoryReservation(client *redis.Client) output.InventoryReservation {
+	return &RedisInventoryReservation{client: client}
+}
+
+func (r *RedisInventoryReservation) Reserve(ctx context.Context, reservationID uuid.UUID, items []domain.StockItem, ttl time.Duration) error {
+	keys := []string{holdKey(reservationID), holdsKey}
+	args := []interface{}{reservationID.String(), time.Now().Add(ttl).UnixMilli()}
+	for _, item := range items {
+		keys = append(keys, stockKey(item.ProductID))
+		args = append(args, item.Quantity)
+	}
+
+	failed, err := reserveScript.Run(ctx, r.client, keys, args...).Int()
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return &domain.InsufficientStockError{ProductID: items[failed-1].ProductID}
+	}
+
+	return nil
+}
+
+func (r *RedisInventoryReservation) Confirm(ctx context.Context, reservationID uuid.UUID) error {
+	confirmed, err := confirmScript.Run(ctx, r.client, []string{holdKey(reservationID), holdsKey}, reservationID.String()).Int()
+	if err != nil {
+		return err
+	}
+
+	if confirmed == 0 {
+		return domain.ErrReservationNotFound
+	}
+
+	return nil
+}
+
+func (r *RedisInventoryReservation) Release(ctx context.Context, reservationID uuid.UUID) error {
+	return releaseScript.Run(ctx, r.client, []string{holdsKey}, holdKeyPrefix, reservationID.String()).Err()
+}
+
+func (r *RedisInventoryReservation) ReleaseExpired(ctx context.Context, now time.Time) (int, error) {
+	return releaseExpiredScript.Run(ctx, r.client, []string{holdsKey}, holdKeyPrefix, now.UnixMilli(), releaseExpiredBatch).Int()
+}
+
+func (r *RedisInventoryReservation) SeedStock(ctx context.Context, productID uuid.UUID, quantity int) error {
+	return r.client.SetNX(ctx, stockKey(productID), strconv.Itoa(quantity), 0).Err()
+}
+
+func stockKey(productID uuid.UUID) string {
+	return stockKeyPrefix + productID.String()
+}
+
+func holdKey(reservationID uuid.UUID) string {
+	return holdKeyPrefix + reservationID.String()
+}
@@ -0,0 +1,75 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+func newTestRedisReservation(t *testing.T) (*RedisInventoryReservation, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisInventoryReservation(client).(*RedisInventoryReservation), server
+}
+
+func TestRedisReserveIsIdempotent(t *testing.T) {
+	// seed 0 leaves the product untracked
+	tests := []struct {
+		name      string
+		seed      int
+		wantStock string
+	}{
+		{name: "tracked product", seed: 5, wantStock: "3"},
+		{name: "untracked product"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			reservations, server := newTestRedisReservation(t)
+
+			productID := uuid.New()
+			if tt.seed > 0 {
+				if err := reservations.SeedStock(ctx, productID, tt.seed); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// The retry comes with a later deadline, it must not replace the first one
+			reservationID := uuid.New()
+			items := []domain.StockItem{{ProductID: productID, Quantity: 2}}
+			for _, ttl := range []time.Duration{time.Minute, time.Hour} {
+				if err := reservations.Reserve(ctx, reservationID, items, ttl); err != nil {
+					t.Fatalf("Reserve: %v", err)
+				}
+			}
+
+			stock, _ := server.Get(stockKey(productID))
+			if stock != tt.wantStock {
+				t.Errorf("got stock %q, want %q with the units held once", stock, tt.wantStock)
+			}
+
+			released, err := reservations.ReleaseExpired(ctx, time.Now().Add(2*time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if released != 1 {
+				t.Errorf("released %d holds, want the first reservation to expire", released)
+			}
+			if tt.seed > 0 {
+				if stock, _ := server.Get(stockKey(productID)); stock != "5" {
+					t.Errorf("got stock %q after the release, want 5", stock)
+				}
+			}
+		})
+	}
+}
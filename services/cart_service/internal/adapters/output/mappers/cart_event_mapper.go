@@ -0,0 +1,85 @@
+package mappers
+
+import (
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+type CartEventMapper struct{}
+
+func (m *CartEventMapper) DomainToModel(event domain.CartEvent) models.CartEventModel {
+	var checkoutID *string
+	if event.CheckoutID != nil {
+		id := event.CheckoutID.String()
+		checkoutID = &id
+	}
+
+	return models.CartEventModel{
+		ID:               event.ID,
+		CartID:           event.CartID.String(),
+		UserID:           event.UserID.String(),
+		Type:             string(event.Type),
+		ItemID:           event.ItemID.String(),
+		ProductID:        event.ProductID.String(),
+		Name:             event.Name,
+		UnitPriceAmount:  event.UnitPrice.Amount(),
+		DiscountAmount:   event.Discount.Amount(),
+		Currency:         event.UnitPrice.Currency(),
+		Quantity:         event.Quantity,
+		PreviousQuantity: event.PreviousQuantity,
+		CheckoutID:       checkoutID,
+		OccurredAt:       event.OccurredAt,
+	}
+}
+
+func (m *CartEventMapper) ModelToDomain(model models.CartEventModel) domain.CartEvent {
+	cartID, _ := uuid.Parse(model.CartID)
+	userID, _ := uuid.Parse(model.UserID)
+	itemID, _ := uuid.Parse(model.ItemID)
+	productID, _ := uuid.Parse(model.ProductID)
+
+	var checkoutID *uuid.UUID
+	if model.CheckoutID != nil {
+		if id, err := uuid.Parse(*model.CheckoutID); err == nil {
+			checkoutID = &id
+		}
+	}
+
+	return domain.CartEvent{
+		ID:               model.ID,
+		CartID:           cartID,
+		UserID:           userID,
+		Type:             domain.CartEventType(model.Type),
+		ItemID:           itemID,
+		ProductID:        productID,
+		Name:             model.Name,
+		UnitPrice:        money.New(model.UnitPriceAmount, model.Currency),
+		Discount:         money.New(model.DiscountAmount, model.Currency),
+		Quantity:         model.Quantity,
+		PreviousQuantity: model.PreviousQuantity,
+		CheckoutID:       checkoutID,
+		OccurredAt:       model.OccurredAt,
+	}
+}
+
+func (m *CartEventMapper) DomainsToDTOs(events []domain.CartEvent) []dtos.CartEventDTO {
+	eventDTOs := make([]dtos.CartEventDTO, len(events))
+	for i, event := range events {
+		eventDTOs[i] = dtos.CartEventDTO{
+			Type:             string(event.Type),
+			ItemID:           event.ItemID,
+			ProductID:        event.ProductID,
+			Name:             event.Name,
+			UnitPrice:        event.UnitPrice,
+			Discount:         event.Discount,
+			Quantity:         event.Quantity,
+			PreviousQuantity: event.PreviousQuantity,
+			CheckoutID:       event.CheckoutID,
+			OccurredAt:       event.OccurredAt,
+		}
+	}
+	return eventDTOs
+}
@@ -7,43 +7,187 @@ import (
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
 	"github.com/google/uuid"
 )
 
 type CartMapper struct {
-	itemMapper CartItemMapper
+	itemMapper      CartItemMapper
+	promotionMapper PromotionMapper
+	checkoutMapper  CheckoutMapper
 }
 
 func (m *CartMapper) DomainToModel(domain domain.Cart) *models.CartModel {
+	var promotionID *string
+	if domain.Promotion != nil {
+		id := domain.Promotion.ID.String()
+		promotionID = &id
+	}
+
 	return &models.CartModel{
+		ID:              domain.ID.String(),
+		UserID:          domain.UserID.String(),
+		Items:           m.itemMapper.domainsToModels(domain.Items),
+		SavedItems:      m.itemMapper.domainsToSavedModels(domain.SavedItems),
+		PromotionID:     promotionID,
+		Version:         domain.Version,
+		DisplayCurrency: domain.DisplayCurrency,
+		CheckoutFrozen:  domain.CheckoutFrozen,
+		CreatedAt:       domain.CreatedAt,
+		UpdatedAt:       domain.UpdatedAt,
+	}
+}
+
+func (m *CartMapper) ModelToDomain(model models.CartModel) *domain.Cart {
+	id, _ := uuid.Parse(model.ID)
+	userId, _ := uuid.Parse(model.UserID)
+	cart := &domain.Cart{
+		ID:              id,
+		UserID:          userId,
+		Items:           m.itemMapper.modelsToDomains(model.Items),
+		SavedItems:      m.itemMapper.savedModelsToDomains(model.SavedItems),
+		Version:         model.Version,
+		DisplayCurrency: model.DisplayCurrency,
+		CheckoutFrozen:  model.CheckoutFrozen,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+
+	if model.Promotion != nil {
+		cart.Promotion = m.promotionMapper.ModelToDomain(*model.Promotion)
+	}
+
+	return cart
+}
+
+func (m *CartMapper) DomainToGuestModel(domain domain.Cart) *models.GuestCartModel {
+	return &models.GuestCartModel{
 		ID:        domain.ID.String(),
-		UserID:    domain.UserID.String(),
 		Items:     m.itemMapper.domainsToModels(domain.Items),
 		CreatedAt: domain.CreatedAt,
 		UpdatedAt: domain.UpdatedAt,
 	}
 }
 
-func (m *CartMapper) ModelToDomain(model models.CartModel) *domain.Cart {
+func (m *CartMapper) GuestModelToDomain(model models.GuestCartModel) *domain.Cart {
 	id, _ := uuid.Parse(model.ID)
-	userId, _ := uuid.Parse(model.UserID)
 	return &domain.Cart{
 		ID:        id,
-		UserID:    userId,
+		UserID:    uuid.Nil,
 		Items:     m.itemMapper.modelsToDomains(model.Items),
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
 	}
 }
 
-func (m *CartMapper) DomainToDTO(model domain.Cart) *dtos.CartDTO {
-	subTotal, _ := model.CalculateTotal()
-	return &dtos.CartDTO{
-		ID:       model.ID,
-		UserID:   model.UserID,
-		Items:    m.itemMapper.domainsToDTOs(model.Items),
-		SubTotal: subTotal,
+func (m *CartMapper) DomainToDTO(model domain.Cart) (*dtos.CartDTO, error) {
+	pricing, err := model.Price(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// The amounts of an empty cart have no currency, they are shown in the cart currency
+	currency := model.Currency()
+	total := money.New(pricing.Total.Amount(), currency)
+	promotionDiscount := money.New(pricing.PromotionDiscount.Amount(), currency)
+
+	// The sub total shown is after the item discounts and the pricing corrections, only the coupon comes after it
+	subTotal := money.New(total.Amount()+promotionDiscount.Amount(), currency)
+
+	cartDTO := &dtos.CartDTO{
+		ID:                model.ID,
+		UserID:            model.UserID,
+		Items:             m.itemMapper.pricedLinesToDTOs(pricing.Lines),
+		Promotions:        m.promotionMapper.DiscountsToDTOs(pricing.PromotionDiscounts),
+		Version:           model.Version,
+		SubTotal:          subTotal,
+		PromotionDiscount: promotionDiscount,
+		Total:             total,
+		TaxLines:          []dtos.TaxLineDTO{},
+		TaxTotal:          money.Zero(currency),
+		CheckoutFrozen:    model.CheckoutFrozen,
+	}
+	cartDTO.GrandTotal = cartDTO.Total
+
+	if model.Promotion != nil {
+		cartDTO.CouponCode = model.Promotion.Code
+	}
+
+	return cartDTO, nil
+}
+
+// ApplyTaxes adds the tax lines to a cart DTO built by DomainToDTO and updates its grand total.
+// The DTO is left untaxed when a line is not in the currency of the cart.
+func (m *CartMapper) ApplyTaxes(cartDTO *dtos.CartDTO, lines []domain.TaxLine) error {
+	taxTotal, err := domain.TotalTax(lines, cartDTO.Total.Currency())
+	if err != nil {
+		return err
+	}
+
+	grandTotal, err := cartDTO.Total.Add(taxTotal)
+	if err != nil {
+		return err
+	}
+
+	cartDTO.TaxLines = make([]dtos.TaxLineDTO, len(lines))
+	for i, line := range lines {
+		cartDTO.TaxLines[i] = dtos.TaxLineDTO{
+			Name:   line.Name,
+			Rate:   line.Rate,
+			Amount: line.Amount,
+		}
+	}
+
+	cartDTO.TaxTotal = taxTotal
+	cartDTO.GrandTotal = grandTotal
+	return nil
+}
+
+// ApplyExchangeRate adds the totals of a cart DTO, taxes included, converted at rate.
+func (m *CartMapper) ApplyExchangeRate(cartDTO *dtos.CartDTO, rate domain.ExchangeRate) {
+	cartDTO.Display = &dtos.CartDisplayDTO{
+		Currency:          rate.Quote,
+		Rate:              rate.Rate,
+		RateAsOf:          rate.AsOf,
+		SubTotal:          rate.Convert(cartDTO.SubTotal),
+		PromotionDiscount: rate.Convert(cartDTO.PromotionDiscount),
+		Total:             rate.Convert(cartDTO.Total),
+		TaxTotal:          rate.Convert(cartDTO.TaxTotal),
+		GrandTotal:        rate.Convert(cartDTO.GrandTotal),
+	}
+}
+
+func (m *CartMapper) DomainToAbandonedEvent(cart domain.Cart, detectedAt time.Time) (dtos.CartAbandonedEvent, error) {
+	subTotal, err := cart.CalculateTotal()
+	if err != nil {
+		return dtos.CartAbandonedEvent{}, err
 	}
+
+	return dtos.CartAbandonedEvent{
+		CartID:         cart.ID,
+		UserID:         cart.UserID,
+		Items:          m.checkoutMapper.itemsToDTOs(cart.Items),
+		SubTotal:       subTotal,
+		LastActivityAt: cart.UpdatedAt,
+		DetectedAt:     detectedAt,
+	}, nil
+}
+
+func (m *CartMapper) ChangesToDTOs(changes []domain.ItemChange) []dtos.ItemChangeDTO {
+	changeDTOs := make([]dtos.ItemChangeDTO, len(changes))
+	for i, change := range changes {
+		changeDTOs[i] = dtos.ItemChangeDTO{
+			ItemID:       change.ItemID,
+			ProductID:    change.ProductID,
+			Name:         change.Name,
+			Reason:       string(change.Reason),
+			OldUnitPrice: change.OldUnitPrice,
+			NewUnitPrice: change.NewUnitPrice,
+			OldDiscount:  change.OldDiscount,
+			NewDiscount:  change.NewDiscount,
+		}
+	}
+	return changeDTOs
 }
 
 type CartItemMapper struct{}
@@ -56,6 +200,22 @@ func (m *CartItemMapper) domainsToModels(items []domain.CartItem) []models.CartI
 	return models
 }
 
+func (m *CartItemMapper) domainsToSavedModels(items []domain.CartItem) []models.SavedItemModel {
+	savedModels := make([]models.SavedItemModel, len(items))
+	for i, item := range items {
+		savedModels[i] = models.SavedItemModel(*m.domainToModel(item))
+	}
+	return savedModels
+}
+
+func (m *CartItemMapper) savedModelsToDomains(savedModels []models.SavedItemModel) []domain.CartItem {
+	domains := make([]domain.CartItem, len(savedModels))
+	for i, model := range savedModels {
+		domains[i] = *m.modelToDomain(models.CartItemModel(model))
+	}
+	return domains
+}
+
 func (m *CartItemMapper) modelsToDomains(models []models.CartItemModel) []domain.CartItem {
 	domains := make([]domain.CartItem, len(models))
 	for i, model := range models {
@@ -67,14 +227,16 @@ func (m *CartItemMapper) modelsToDomains(models []models.CartItemModel) []domain
 
 func (m *CartItemMapper) domainToModel(domain domain.CartItem) *models.CartItemModel {
 	return &models.CartItemModel{
-		ID:        domain.ID.String(),
-		CartID:    domain.CartID.String(),
-		ProductID: domain.ProductID.String(),
-		Name:      domain.Name,
-		UnitPrice: domain.UnitPrice,
-		Quantity:  domain.Quantity,
-		Discount:  domain.Discount,
-		AddedAt:   domain.AddedAt,
+		ID:              domain.ID.String(),
+		CartID:          domain.CartID.String(),
+		ProductID:       domain.ProductID.String(),
+		ProductType:     string(domain.ProductType),
+		Name:            domain.Name,
+		UnitPriceAmount: domain.UnitPrice.Amount(),
+		Quantity:        domain.Quantity,
+		DiscountAmount:  domain.Discount.Amount(),
+		Currency:        domain.UnitPrice.Currency(),
+		AddedAt:         domain.AddedAt,
 	}
 }
 
@@ -84,30 +246,37 @@ func (m *CartItemMapper) modelToDomain(model models.CartItemModel) *domain.CartI
 	productId, _ := uuid.Parse(model.ProductID)
 
 	return &domain.CartItem{
-		ID:        id,
-		CartID:    cartId,
-		Name:      model.Name,
-		ProductID: productId,
-		UnitPrice: model.UnitPrice,
-		Quantity:  model.Quantity,
-		Discount:  model.Discount,
-		AddedAt:   model.AddedAt,
+		ID:          id,
+		CartID:      cartId,
+		Name:        model.Name,
+		ProductID:   productId,
+		ProductType: domain.ParseProductType(model.ProductType),
+		UnitPrice:   money.New(model.UnitPriceAmount, model.Currency),
+		Quantity:    model.Quantity,
+		Discount:    money.New(model.DiscountAmount, model.Currency),
+		AddedAt:     model.AddedAt,
 	}
 }
 
 func (m *CartItemMapper) productToDomain(product facadeService.Product, quantity uint, cartID uuid.UUID) *domain.CartItem {
 	return &domain.CartItem{
-		ID:        uuid.New(),
-		CartID:    cartID,
-		ProductID: product.Id,
-		Name:      product.Name,
-		UnitPrice: product.Price,
-		Quantity:  int(quantity),
-		Discount:  product.Disccount,
-		AddedAt:   time.Now(),
+		ID:          uuid.New(),
+		CartID:      cartID,
+		ProductID:   product.Id,
+		ProductType: domain.ParseProductType(product.Type),
+		Name:        product.Name,
+		UnitPrice:   product.Price,
+		Quantity:    int(quantity),
+		Discount:    product.Disccount,
+		MaxQuantity: product.MaxQuantity,
+		AddedAt:     time.Now(),
 	}
 }
 
+func (m *CartItemMapper) ProductToItem(product facadeService.Product, quantity int, cartID uuid.UUID) domain.CartItem {
+	return *m.productToDomain(product, uint(quantity), cartID)
+}
+
 func (m *CartItemMapper) ProductToItemList(products []dtos.CartItemFetchedDTO, cartID uuid.UUID) []domain.CartItem {
 	items := make([]domain.CartItem, len(products))
 
@@ -119,23 +288,53 @@ func (m *CartItemMapper) ProductToItemList(products []dtos.CartItemFetchedDTO, c
 	return items
 }
 
-func (m *CartItemMapper) domainsToDTOs(models []domain.CartItem) []dtos.CartItemDTO {
-	domains := make([]dtos.CartItemDTO, len(models))
-	for i, model := range models {
-		domains[i] = *m.domainToDTO(model)
+// SavedItemToDTO maps a saved item. product is its current catalog data, nil when the catalog no longer has it.
+func (m *CartItemMapper) SavedItemToDTO(item domain.CartItem, product *facadeService.Product) dtos.SavedItemDTO {
+	savedDTO := dtos.SavedItemDTO{
+		ID:         item.ID,
+		ProductID:  item.ProductID,
+		Name:       item.Name,
+		Quantity:   item.Quantity,
+		SavedPrice: item.UnitPrice,
+		SavedAt:    item.AddedAt,
 	}
 
-	return domains
+	if product != nil {
+		savedDTO.CurrentPrice = product.Price
+		savedDTO.CurrentDiscount = product.Disccount
+		savedDTO.IsAvailable = product.IsAvalaible
+	}
+
+	return savedDTO
 }
 
 func (m *CartItemMapper) domainToDTO(domain domain.CartItem) *dtos.CartItemDTO {
 	return &dtos.CartItemDTO{
-		ID:        domain.ID,
-		CartID:    domain.CartID,
-		Name:      domain.Name,
-		ProductID: domain.ProductID,
-		UnitPrice: domain.UnitPrice,
-		Quantity:  domain.Quantity,
-		Discount:  domain.Discount,
+		ID:          domain.ID,
+		CartID:      domain.CartID,
+		Name:        domain.Name,
+		ProductID:   domain.ProductID,
+		ProductType: string(domain.ProductType),
+		UnitPrice:   domain.UnitPrice,
+		Quantity:    domain.Quantity,
+		Discount:    domain.Discount,
+	}
+}
+
+func (m *CartItemMapper) pricedLinesToDTOs(lines []domain.PricedLine) []dtos.CartItemDTO {
+	itemDTOs := make([]dtos.CartItemDTO, len(lines))
+	for i, line := range lines {
+		itemDTO := m.domainToDTO(line.Item)
+		itemDTO.LineTotal = line.Total
+		itemDTO.Pricing = make([]dtos.PriceAdjustmentDTO, len(line.Explanation))
+		for j, adjustment := range line.Explanation {
+			itemDTO.Pricing[j] = dtos.PriceAdjustmentDTO{
+				Step:        adjustment.Step,
+				Amount:      adjustment.Amount,
+				Description: adjustment.Description,
+			}
+		}
+		itemDTOs[i] = *itemDTO
 	}
+	return itemDTOs
 }
@@ -0,0 +1,99 @@
+package mappers
+
+import (
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+type CartSnapshotMapper struct {
+	itemMapper CartItemMapper
+}
+
+func (m *CartSnapshotMapper) DomainToModel(snapshot domain.CartSnapshot) *models.CartSnapshotModel {
+	items := make([]models.CartSnapshotItemModel, len(snapshot.Items))
+	for i, item := range snapshot.Items {
+		items[i] = models.CartSnapshotItemModel{
+			ID:              item.ID.String(),
+			SnapshotID:      snapshot.ID.String(),
+			ProductID:       item.ProductID.String(),
+			ProductType:     string(item.ProductType),
+			Name:            item.Name,
+			UnitPriceAmount: item.UnitPrice.Amount(),
+			Quantity:        item.Quantity,
+			DiscountAmount:  item.Discount.Amount(),
+			Currency:        item.UnitPrice.Currency(),
+		}
+	}
+
+	return &models.CartSnapshotModel{
+		ID:        snapshot.ID.String(),
+		TokenHash: snapshot.TokenHash,
+		CartID:    snapshot.CartID.String(),
+		OwnerID:   snapshot.OwnerID.String(),
+		Items:     items,
+		CreatedAt: snapshot.CreatedAt,
+		ExpiresAt: snapshot.ExpiresAt,
+	}
+}
+
+func (m *CartSnapshotMapper) ModelToDomain(model models.CartSnapshotModel) *domain.CartSnapshot {
+	id, _ := uuid.Parse(model.ID)
+	cartID, _ := uuid.Parse(model.CartID)
+	ownerID, _ := uuid.Parse(model.OwnerID)
+
+	items := make([]domain.CartItem, len(model.Items))
+	for i, itemModel := range model.Items {
+		itemID, _ := uuid.Parse(itemModel.ID)
+		productID, _ := uuid.Parse(itemModel.ProductID)
+		items[i] = domain.CartItem{
+			ID:          itemID,
+			CartID:      id,
+			ProductID:   productID,
+			ProductType: domain.ParseProductType(itemModel.ProductType),
+			Name:        itemModel.Name,
+			UnitPrice:   money.New(itemModel.UnitPriceAmount, itemModel.Currency),
+			Quantity:    itemModel.Quantity,
+			Discount:    money.New(itemModel.DiscountAmount, itemModel.Currency),
+			AddedAt:     model.CreatedAt,
+		}
+	}
+
+	return &domain.CartSnapshot{
+		ID:        id,
+		TokenHash: model.TokenHash,
+		CartID:    cartID,
+		OwnerID:   ownerID,
+		Items:     items,
+		CreatedAt: model.CreatedAt,
+		ExpiresAt: model.ExpiresAt,
+	}
+}
+
+func (m *CartSnapshotMapper) DomainToDTO(snapshot domain.CartSnapshot) (*dtos.CartSnapshotDTO, error) {
+	pricing, err := snapshot.Price()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.CartSnapshotDTO{
+		Items:     m.itemMapper.pricedLinesToDTOs(pricing.Lines),
+		SubTotal:  pricing.Total,
+		CreatedAt: snapshot.CreatedAt,
+		ExpiresAt: snapshot.ExpiresAt,
+	}, nil
+}
+
+// DomainToInsertDTOs turns the snapshot lines into the input of CartUseCase.AddItems.
+func (m *CartSnapshotMapper) DomainToInsertDTOs(snapshot domain.CartSnapshot) []dtos.CartItemInserDTO {
+	insertDTOs := make([]dtos.CartItemInserDTO, len(snapshot.Items))
+	for i, item := range snapshot.Items {
+		insertDTOs[i] = dtos.CartItemInserDTO{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+	return insertDTOs
+}
@@ -0,0 +1,131 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type CheckoutMapper struct{}
+
+func (m *CheckoutMapper) DomainToModel(checkout domain.Checkout) *models.CheckoutModel {
+	items := make([]models.CheckoutItemModel, len(checkout.Items))
+	for i, item := range checkout.Items {
+		items[i] = models.CheckoutItemModel{
+			ID:              uuid.New().String(),
+			CheckoutID:      checkout.ID.String(),
+			ProductID:       item.ProductID.String(),
+			ProductType:     string(item.ProductType),
+			Name:            item.Name,
+			UnitPriceAmount: item.UnitPrice.Amount(),
+			Quantity:        item.Quantity,
+			DiscountAmount:  item.Discount.Amount(),
+			Currency:        item.UnitPrice.Currency(),
+		}
+	}
+
+	var promotionID *string
+	if checkout.PromotionID != nil {
+		id := checkout.PromotionID.String()
+		promotionID = &id
+	}
+
+	var exchangeRate float64
+	var exchangeRateAsOf *time.Time
+	if checkout.ExchangeRate != nil {
+		exchangeRate = checkout.ExchangeRate.Rate
+		exchangeRateAsOf = &checkout.ExchangeRate.AsOf
+	}
+
+	return &models.CheckoutModel{
+		ID:                      checkout.ID.String(),
+		PromotionID:             promotionID,
+		CouponCode:              checkout.CouponCode,
+		PromotionDiscountAmount: checkout.PromotionDiscount.Amount(),
+		CartID:                  checkout.CartID.String(),
+		UserID:                  checkout.UserID.String(),
+		Items:                   items,
+		SubTotalAmount:          checkout.SubTotal.Amount(),
+		TotalDiscountAmount:     checkout.TotalDiscount.Amount(),
+		TotalAmount:             checkout.Total.Amount(),
+		Currency:                checkout.Total.Currency(),
+		DisplayCurrency:         checkout.DisplayCurrency,
+		ExchangeRate:            exchangeRate,
+		ExchangeRateAsOf:        exchangeRateAsOf,
+		PurchasedAt:             checkout.PurchasedAt,
+	}
+}
+
+func (m *CheckoutMapper) DomainToPurchaseDetails(checkout domain.Checkout) dtos.PurchaseDetails {
+	purchase := dtos.PurchaseDetails{
+		CheckoutID:     checkout.ID,
+		CartID:         checkout.CartID,
+		UserID:         checkout.UserID,
+		SubTotal:       checkout.SubTotal,
+		TotalAmount:    checkout.Total,
+		TotalDisscount: checkout.TotalDiscount,
+		CouponCode:     checkout.CouponCode,
+		PromotionTotal: checkout.PromotionDiscount,
+		PurchaseDate:   checkout.PurchasedAt,
+		Items:          m.itemsToDTOs(checkout.Items),
+	}
+
+	if checkout.ExchangeRate != nil {
+		purchase.DisplayCurrency = checkout.DisplayCurrency
+		purchase.ExchangeRate = checkout.ExchangeRate.Rate
+		purchase.ExchangeRateAsOf = &checkout.ExchangeRate.AsOf
+		purchase.DisplayTotal = checkout.DisplayTotal()
+	}
+
+	return purchase
+}
+
+func (m *CheckoutMapper) DomainToOrderDetails(checkout domain.Checkout) dtos.OrderDetails {
+	return dtos.OrderDetails{
+		CheckoutID: checkout.ID,
+		UserID:     checkout.UserID,
+		ItemCount:  uint(checkout.GetItemCount()),
+		CreatedAt:  checkout.PurchasedAt,
+		Items:      m.itemsToDTOs(checkout.Items),
+	}
+}
+
+func (m *CheckoutMapper) DomainToEvent(checkout domain.Checkout) dtos.CheckoutEvent {
+	return dtos.CheckoutEvent{
+		Purchase: m.DomainToPurchaseDetails(checkout),
+		Order:    m.DomainToOrderDetails(checkout),
+	}
+}
+
+// DomainToCoursePurchase returns nil when the checkout holds no course.
+func (m *CheckoutMapper) DomainToCoursePurchase(checkout domain.Checkout) *dtos.CoursePurchaseEvent {
+	courseIDs := checkout.CourseIDs()
+	if len(courseIDs) == 0 {
+		return nil
+	}
+
+	return &dtos.CoursePurchaseEvent{
+		CheckoutID:  checkout.ID,
+		UserID:      checkout.UserID,
+		CourseIDs:   courseIDs,
+		PurchasedAt: checkout.PurchasedAt,
+	}
+}
+
+func (m *CheckoutMapper) itemsToDTOs(items []domain.CartItem) []dtos.ItemDTO {
+	itemDTOs := make([]dtos.ItemDTO, len(items))
+	for i, item := range items {
+		itemDTOs[i] = dtos.ItemDTO{
+			ProductID:   item.ProductID,
+			ProductType: string(item.ProductType),
+			Name:        item.Name,
+			UnitPrice:   item.UnitPrice,
+			Quantity:    item.Quantity,
+			Discount:    item.Discount,
+		}
+	}
+	return itemDTOs
+}
@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+type OutboxMapper struct{}
+
+func (m *OutboxMapper) DomainToModel(event domain.OutboxEvent, status string) *models.OutboxEventModel {
+	return &models.OutboxEventModel{
+		ID:          event.ID.String(),
+		AggregateID: event.AggregateID.String(),
+		EventType:   event.EventType,
+		Payload:     event.Payload,
+		Status:      status,
+		Attempts:    event.Attempts,
+		LastError:   event.LastError,
+		CreatedAt:   event.CreatedAt,
+		SentAt:      event.SentAt,
+	}
+}
+
+func (m *OutboxMapper) ModelToDomain(model models.OutboxEventModel) domain.OutboxEvent {
+	id, _ := uuid.Parse(model.ID)
+	aggregateID, _ := uuid.Parse(model.AggregateID)
+	return domain.OutboxEvent{
+		ID:          id,
+		AggregateID: aggregateID,
+		EventType:   model.EventType,
+		Payload:     model.Payload,
+		Attempts:    model.Attempts,
+		LastError:   model.LastError,
+		CreatedAt:   model.CreatedAt,
+		SentAt:      model.SentAt,
+	}
+}
@@ -0,0 +1,153 @@
+package mappers
+
+import (
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+type PromotionMapper struct{}
+
+func (m *PromotionMapper) DomainToModel(promotion domain.Promotion) *models.PromotionModel {
+	var productID *string
+	if promotion.ProductID != nil {
+		id := promotion.ProductID.String()
+		productID = &id
+	}
+
+	currency := promotion.Amount.Currency()
+	if currency == "" {
+		currency = promotion.MinSubtotal.Currency()
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	return &models.PromotionModel{
+		ID:                promotion.ID.String(),
+		Code:              promotion.Code,
+		Description:       promotion.Description,
+		Type:              string(promotion.Type),
+		Percentage:        promotion.Percentage,
+		AmountValue:       promotion.Amount.Amount(),
+		BuyQuantity:       promotion.BuyQuantity,
+		FreeQuantity:      promotion.FreeQuantity,
+		ProductID:         productID,
+		MinSubtotalAmount: promotion.MinSubtotal.Amount(),
+		Currency:          currency,
+		StartsAt:          promotion.StartsAt,
+		ExpiresAt:         promotion.ExpiresAt,
+		UsageLimitPerUser: promotion.UsageLimitPerUser,
+		IsActive:          promotion.IsActive,
+		CreatedAt:         promotion.CreatedAt,
+		UpdatedAt:         promotion.UpdatedAt,
+	}
+}
+
+func (m *PromotionMapper) ModelToDomain(model models.PromotionModel) *domain.Promotion {
+	id, _ := uuid.Parse(model.ID)
+
+	var productID *uuid.UUID
+	if model.ProductID != nil {
+		parsed, _ := uuid.Parse(*model.ProductID)
+		productID = &parsed
+	}
+
+	return &domain.Promotion{
+		ID:                id,
+		Code:              model.Code,
+		Description:       model.Description,
+		Type:              domain.PromotionType(model.Type),
+		Percentage:        model.Percentage,
+		Amount:            m.amountOrEmpty(model.AmountValue, model.Currency),
+		BuyQuantity:       model.BuyQuantity,
+		FreeQuantity:      model.FreeQuantity,
+		ProductID:         productID,
+		MinSubtotal:       m.amountOrEmpty(model.MinSubtotalAmount, model.Currency),
+		StartsAt:          model.StartsAt,
+		ExpiresAt:         model.ExpiresAt,
+		UsageLimitPerUser: model.UsageLimitPerUser,
+		IsActive:          model.IsActive,
+		CreatedAt:         model.CreatedAt,
+		UpdatedAt:         model.UpdatedAt,
+	}
+}
+
+func (m *PromotionMapper) InsertDTOToDomain(dto dtos.PromotionInsertDTO) domain.Promotion {
+	return domain.Promotion{
+		Code:              dto.Code,
+		Description:       dto.Description,
+		Type:              domain.PromotionType(dto.Type),
+		Percentage:        dto.Percentage,
+		Amount:            dto.Amount,
+		BuyQuantity:       dto.BuyQuantity,
+		FreeQuantity:      dto.FreeQuantity,
+		ProductID:         dto.ProductID,
+		MinSubtotal:       dto.MinSubtotal,
+		StartsAt:          dto.StartsAt,
+		ExpiresAt:         dto.ExpiresAt,
+		UsageLimitPerUser: dto.UsageLimitPerUser,
+		IsActive:          dto.IsActive,
+	}
+}
+
+func (m *PromotionMapper) DomainToDTO(promotion domain.Promotion) *dtos.PromotionDTO {
+	return &dtos.PromotionDTO{
+		ID:                promotion.ID,
+		Code:              promotion.Code,
+		Description:       promotion.Description,
+		Type:              string(promotion.Type),
+		Percentage:        promotion.Percentage,
+		Amount:            promotion.Amount,
+		BuyQuantity:       promotion.BuyQuantity,
+		FreeQuantity:      promotion.FreeQuantity,
+		ProductID:         promotion.ProductID,
+		MinSubtotal:       promotion.MinSubtotal,
+		StartsAt:          promotion.StartsAt,
+		ExpiresAt:         promotion.ExpiresAt,
+		UsageLimitPerUser: promotion.UsageLimitPerUser,
+		IsActive:          promotion.IsActive,
+	}
+}
+
+func (m *PromotionMapper) DomainsToDTOs(promotions []domain.Promotion) []dtos.PromotionDTO {
+	promotionDTOs := make([]dtos.PromotionDTO, len(promotions))
+	for i, promotion := range promotions {
+		promotionDTOs[i] = *m.DomainToDTO(promotion)
+	}
+	return promotionDTOs
+}
+
+func (m *PromotionMapper) RedemptionToModel(redemption domain.PromotionRedemption) *models.PromotionRedemptionModel {
+	return &models.PromotionRedemptionModel{
+		ID:          redemption.ID.String(),
+		PromotionID: redemption.PromotionID.String(),
+		UserID:      redemption.UserID.String(),
+		CheckoutID:  redemption.CheckoutID.String(),
+		RedeemedAt:  redemption.RedeemedAt,
+	}
+}
+
+func (m *PromotionMapper) DiscountsToDTOs(discounts []domain.PromotionDiscount) []dtos.PromotionDiscountDTO {
+	discountDTOs := make([]dtos.PromotionDiscountDTO, len(discounts))
+	for i, discount := range discounts {
+		discountDTOs[i] = dtos.PromotionDiscountDTO{
+			PromotionID: discount.PromotionID,
+			Code:        discount.Code,
+			ItemID:      discount.ItemID,
+			ProductID:   discount.ProductID,
+			Amount:      discount.Amount,
+		}
+	}
+	return discountDTOs
+}
+
+// Zero amounts are left without currency so percentage and buy-x-get-y coupons work in any currency.
+func (m *PromotionMapper) amountOrEmpty(amount int64, currency string) money.Money {
+	if amount == 0 {
+		return money.Money{}
+	}
+	return money.New(amount, currency)
+}
@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+type UserEventMapper struct{}
+
+// DTOToDomain validates the event read from the queue, see domain.NewUserEvent.
+func (m *UserEventMapper) DTOToDomain(eventDTO dtos.UserEventDTO) (*domain.UserEvent, error) {
+	return domain.NewUserEvent(eventDTO.EventID, domain.UserEventType(eventDTO.EventType), eventDTO.UserID, eventDTO.OccurredAt)
+}
+
+func (m *UserEventMapper) DomainToProcessedModel(event domain.UserEvent, processedAt time.Time) *models.ProcessedUserEventModel {
+	return &models.ProcessedUserEventModel{
+		EventID:     event.ID.String(),
+		EventType:   string(event.Type),
+		UserID:      event.UserID.String(),
+		OccurredAt:  event.OccurredAt,
+		ProcessedAt: processedAt,
+	}
+}
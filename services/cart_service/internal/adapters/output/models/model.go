@@ -8,11 +8,20 @@ import (
 )
 
 type CartModel struct {
-	ID        string          `gorm:"type:char(36);primaryKey"`
-	UserID    string          `gorm:"type:char(36);not null;uniqueIndex"`
-	Items     []CartItemModel `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          string           `gorm:"type:char(36);primaryKey"`
+	UserID      string           `gorm:"type:char(36);not null;uniqueIndex"`
+	Items       []CartItemModel  `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE"`
+	SavedItems  []SavedItemModel `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE"`
+	PromotionID *string          `gorm:"type:char(36);index"`
+	Promotion   *PromotionModel  `gorm:"foreignKey:PromotionID;constraint:OnDelete:SET NULL"`
+	Version     int              `gorm:"not null;default:1"`
+	// DisplayCurrency is empty when the totals are only shown in the cart currency
+	DisplayCurrency string `gorm:"type:char(3);not null;default:''"`
+	CheckoutFrozen  bool   `gorm:"not null;default:false"`
+	// AbandonedNotifiedAt is when the reminder for the last idle period was sent, nil until the first one
+	AbandonedNotifiedAt *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 }
 
 func (CartModel) TableName() string {
@@ -20,20 +29,29 @@ func (CartModel) TableName() string {
 }
 
 type CartItemModel struct {
-	ID        string  `gorm:"type:char(36);primaryKey"`
-	CartID    string  `gorm:"type:char(36);not null;index"`
-	ProductID string  `gorm:"type:char(36);not null;index"`
-	Name      string  `gorm:"size:255;not null"`
-	UnitPrice float64 `gorm:"not null"`
-	Quantity  int     `gorm:"not null"`
-	Discount  float64 `gorm:"default:0"`
-	AddedAt   time.Time
+	ID              string `gorm:"type:char(36);primaryKey"`
+	CartID          string `gorm:"type:char(36);not null;index;index:idx_cart_product,priority:1"`
+	ProductID       string `gorm:"type:char(36);not null;index;index:idx_cart_product,priority:2"`
+	ProductType     string `gorm:"size:16;not null;default:'PHYSICAL'"`
+	Name            string `gorm:"size:255;not null"`
+	UnitPriceAmount int64  `gorm:"not null;default:0"`
+	Quantity        int    `gorm:"not null"`
+	DiscountAmount  int64  `gorm:"not null;default:0"`
+	Currency        string `gorm:"type:char(3);not null;default:'USD'"`
+	AddedAt         time.Time
 }
 
 func (CartItemModel) TableName() string {
 	return "cart_items"
 }
 
+// SavedItemModel is a saved for later line. It has the same columns as a cart item.
+type SavedItemModel CartItemModel
+
+func (SavedItemModel) TableName() string {
+	return "cart_saved_items"
+}
+
 func (c *CartModel) BeforeCreate(tx *gorm.DB) (err error) {
 	if c.ID == "" {
 		c.ID = uuid.New().String()
@@ -47,11 +65,172 @@ func (ci *CartItemModel) BeforeCreate(tx *gorm.DB) (err error) {
 	if ci.ID == "" {
 		ci.ID = uuid.New().String()
 	}
-	ci.AddedAt = time.Now()
+	if ci.AddedAt.IsZero() {
+		ci.AddedAt = time.Now()
+	}
 	return
 }
 
-func (CartItemModel) AfterCreate(tx *gorm.DB) (err error) {
-	tx.Exec("CREATE INDEX IF NOT EXISTS idx_cart_product ON cart_items (cart_id, product_id)")
-	return
+type CheckoutModel struct {
+	ID                      string              `gorm:"type:char(36);primaryKey"`
+	CartID                  string              `gorm:"type:char(36);not null;index"`
+	UserID                  string              `gorm:"type:char(36);not null;index"`
+	Items                   []CheckoutItemModel `gorm:"foreignKey:CheckoutID;constraint:OnDelete:CASCADE"`
+	PromotionID             *string             `gorm:"type:char(36);index"`
+	CouponCode              string              `gorm:"size:64"`
+	PromotionDiscountAmount int64               `gorm:"not null;default:0"`
+	SubTotalAmount          int64               `gorm:"not null;default:0"`
+	TotalDiscountAmount     int64               `gorm:"not null;default:0"`
+	TotalAmount             int64               `gorm:"not null;default:0"`
+	Currency                string              `gorm:"type:char(3);not null;default:'USD'"`
+	DisplayCurrency         string              `gorm:"type:char(3);not null;default:''"`
+	ExchangeRate            float64             `gorm:"type:decimal(20,10);not null;default:0"`
+	ExchangeRateAsOf        *time.Time
+	PurchasedAt             time.Time
+}
+
+func (CheckoutModel) TableName() string {
+	return "checkouts"
+}
+
+type CheckoutItemModel struct {
+	ID              string `gorm:"type:char(36);primaryKey"`
+	CheckoutID      string `gorm:"type:char(36);not null;index"`
+	ProductID       string `gorm:"type:char(36);not null"`
+	ProductType     string `gorm:"size:16;not null;default:'PHYSICAL'"`
+	Name            string `gorm:"size:255;not null"`
+	UnitPriceAmount int64  `gorm:"not null;default:0"`
+	Quantity        int    `gorm:"not null"`
+	DiscountAmount  int64  `gorm:"not null;default:0"`
+	Currency        string `gorm:"type:char(3);not null;default:'USD'"`
+}
+
+func (CheckoutItemModel) TableName() string {
+	return "checkout_items"
+}
+
+type OutboxEventModel struct {
+	ID          string    `gorm:"type:char(36);primaryKey"`
+	AggregateID string    `gorm:"type:char(36);not null;index"`
+	EventType   string    `gorm:"size:64;not null"`
+	Payload     []byte    `gorm:"type:blob;not null"`
+	Status      string    `gorm:"size:16;not null;index:idx_outbox_status_created,priority:1"`
+	Attempts    int       `gorm:"default:0"`
+	LastError   string    `gorm:"size:512"`
+	CreatedAt   time.Time `gorm:"index:idx_outbox_status_created,priority:2"`
+	SentAt      *time.Time
+}
+
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
+
+// CartEventModel rows are only ever inserted. ID gives the order of the events of a cart.
+// There is no foreign key to the cart, the history is kept after the cart is deleted.
+type CartEventModel struct {
+	ID               uint64    `gorm:"primaryKey;autoIncrement"`
+	CartID           string    `gorm:"type:char(36);not null;index:idx_cart_event_cart,priority:1"`
+	UserID           string    `gorm:"type:char(36);not null;index"`
+	Type             string    `gorm:"size:32;not null"`
+	ItemID           string    `gorm:"type:char(36);not null"`
+	ProductID        string    `gorm:"type:char(36);not null"`
+	Name             string    `gorm:"size:255;not null"`
+	UnitPriceAmount  int64     `gorm:"not null;default:0"`
+	DiscountAmount   int64     `gorm:"not null;default:0"`
+	Currency         string    `gorm:"type:char(3);not null;default:'USD'"`
+	Quantity         int       `gorm:"not null"`
+	PreviousQuantity int       `gorm:"not null"`
+	CheckoutID       *string   `gorm:"type:char(36)"`
+	OccurredAt       time.Time `gorm:"not null;index:idx_cart_event_cart,priority:2"`
+}
+
+func (CartEventModel) TableName() string {
+	return "cart_events"
+}
+
+type CartSnapshotModel struct {
+	ID        string                  `gorm:"type:char(36);primaryKey"`
+	TokenHash string                  `gorm:"type:char(64);not null;uniqueIndex"`
+	CartID    string                  `gorm:"type:char(36);not null;index"`
+	OwnerID   string                  `gorm:"type:char(36);not null;index"`
+	Items     []CartSnapshotItemModel `gorm:"foreignKey:SnapshotID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (CartSnapshotModel) TableName() string {
+	return "cart_snapshots"
+}
+
+type CartSnapshotItemModel struct {
+	ID              string `gorm:"type:char(36);primaryKey"`
+	SnapshotID      string `gorm:"type:char(36);not null;index"`
+	ProductID       string `gorm:"type:char(36);not null"`
+	ProductType     string `gorm:"size:16;not null;default:'PHYSICAL'"`
+	Name            string `gorm:"size:255;not null"`
+	UnitPriceAmount int64  `gorm:"not null;default:0"`
+	Quantity        int    `gorm:"not null"`
+	DiscountAmount  int64  `gorm:"not null;default:0"`
+	Currency        string `gorm:"type:char(3);not null;default:'USD'"`
+}
+
+func (CartSnapshotItemModel) TableName() string {
+	return "cart_snapshot_items"
+}
+
+type PromotionModel struct {
+	ID                string  `gorm:"type:char(36);primaryKey"`
+	Code              string  `gorm:"size:64;not null;uniqueIndex"`
+	Description       string  `gorm:"size:255"`
+	Type              string  `gorm:"size:32;not null"`
+	Percentage        float64 `gorm:"default:0"`
+	AmountValue       int64   `gorm:"not null;default:0"`
+	BuyQuantity       int     `gorm:"default:0"`
+	FreeQuantity      int     `gorm:"default:0"`
+	ProductID         *string `gorm:"type:char(36)"`
+	MinSubtotalAmount int64   `gorm:"not null;default:0"`
+	Currency          string  `gorm:"type:char(3);not null;default:'USD'"`
+	StartsAt          time.Time
+	ExpiresAt         time.Time `gorm:"index"`
+	UsageLimitPerUser int       `gorm:"default:0"`
+	IsActive          bool      `gorm:"default:true"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (PromotionModel) TableName() string {
+	return "promotions"
+}
+
+type PromotionRedemptionModel struct {
+	ID          string `gorm:"type:char(36);primaryKey"`
+	PromotionID string `gorm:"type:char(36);not null;index:idx_redemption_promotion_user,priority:1"`
+	UserID      string `gorm:"type:char(36);not null;index:idx_redemption_promotion_user,priority:2"`
+	CheckoutID  string `gorm:"type:char(36);not null;uniqueIndex"`
+	RedeemedAt  time.Time
+}
+
+func (PromotionRedemptionModel) TableName() string {
+	return "promotion_redemptions"
+}
+
+// GuestCartModel is the JSON document stored in Redis for a guest cart.
+type GuestCartModel struct {
+	ID        string          `json:"id"`
+	Items     []CartItemModel `json:"items"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ProcessedUserEventModel is a user event that was already applied, keyed by the event id.
+type ProcessedUserEventModel struct {
+	EventID     string    `gorm:"type:char(36);primaryKey"`
+	EventType   string    `gorm:"size:32;not null;index:idx_processed_user_event,priority:2"`
+	UserID      string    `gorm:"type:char(36);not null;index:idx_processed_user_event,priority:1"`
+	OccurredAt  time.Time `gorm:"not null"`
+	ProcessedAt time.Time `gorm:"not null"`
+}
+
+func (ProcessedUserEventModel) TableName() string {
+	return "processed_user_events"
 }
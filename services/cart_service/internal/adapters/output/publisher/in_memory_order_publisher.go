@@ -0,0 +1,47 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+// InMemoryOrderPublisher keeps published events in memory. Meant for tests and local runs.
+type InMemoryOrderPublisher struct {
+	mu     sync.Mutex
+	events []dtos.CheckoutEvent
+	err    error
+}
+
+func NewInMemoryOrderPublisher() *InMemoryOrderPublisher {
+	return &InMemoryOrderPublisher{}
+}
+
+func (p *InMemoryOrderPublisher) PublishOrder(ctx context.Context, event dtos.CheckoutEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return p.err
+	}
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+// FailWith makes every following PublishOrder call return err. Pass nil to recover.
+func (p *InMemoryOrderPublisher) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+func (p *InMemoryOrderPublisher) Events() []dtos.CheckoutEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]dtos.CheckoutEvent, len(p.events))
+	copy(events, p.events)
+	return events
+}
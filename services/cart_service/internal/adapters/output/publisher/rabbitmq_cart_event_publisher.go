@@ -0,0 +1,24 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/rabbitmq"
+	"github.com/streadway/amqp"
+)
+
+const CartAbandonedQueue = "cart_abandoned_queue"
+
+type RabbitMQCartEventPublisher struct {
+	conn *amqp.Connection
+}
+
+func NewRabbitMQCartEventPublisher(conn *amqp.Connection) output.CartEventPublisher {
+	return &RabbitMQCartEventPublisher{conn: conn}
+}
+
+func (p *RabbitMQCartEventPublisher) PublishCartAbandoned(ctx context.Context, event dtos.CartAbandonedEvent) error {
+	return rabbitmq.PublishMessage(p.conn, CartAbandonedQueue, event)
+}
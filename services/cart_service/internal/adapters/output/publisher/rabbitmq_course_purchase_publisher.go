@@ -0,0 +1,25 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/rabbitmq"
+	"github.com/streadway/amqp"
+)
+
+// CoursePurchasesQueue is consumed by course_service, which enrolls the buyers. It has a dead letter queue.
+const CoursePurchasesQueue = "course_purchases_queue"
+
+type RabbitMQCoursePurchasePublisher struct {
+	conn *amqp.Connection
+}
+
+func NewRabbitMQCoursePurchasePublisher(conn *amqp.Connection) output.CoursePurchasePublisher {
+	return &RabbitMQCoursePurchasePublisher{conn: conn}
+}
+
+func (p *RabbitMQCoursePurchasePublisher) PublishCoursesPurchased(ctx context.Context, event dtos.CoursePurchaseEvent) error {
+	return rabbitmq.PublishMessageWithDeadLetter(p.conn, CoursePurchasesQueue, event)
+}
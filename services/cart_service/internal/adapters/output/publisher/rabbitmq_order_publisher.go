@@ -0,0 +1,24 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/rabbitmq"
+	"github.com/streadway/amqp"
+)
+
+const OrderQueue = "order_queue"
+
+type RabbitMQOrderPublisher struct {
+	conn *amqp.Connection
+}
+
+func NewRabbitMQOrderPublisher(conn *amqp.Connection) output.OrderPublisher {
+	return &RabbitMQOrderPublisher{conn: conn}
+}
+
+func (p *RabbitMQOrderPublisher) PublishOrder(ctx context.Context, event dtos.CheckoutEvent) error {
+	return rabbitmq.PublishMessage(p.conn, OrderQueue, event)
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	cartCacheByIDPrefix   = "cart_cache:id:"
+	cartCacheByUserPrefix = "cart_cache:user:"
+)
+
+// CartCacheStats counts how the cache answered reads since the service started.
+// SharedLoads are misses that waited on a load already running for the same key instead of querying MySQL.
+type CartCacheStats struct {
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	SharedLoads uint64 `json:"shared_loads"`
+	Errors      uint64 `json:"errors"`
+}
+
+// CachedCartRepository is a read-through Redis cache in front of another CartRepository.
+// Carts are cached by id and by user id, and both keys are dropped on every write and again after the write commits.
+// Reads inside a transaction and carts with a coupon skip the cache: promotions change without touching the cart.
+type CachedCartRepository struct {
+	output.CartRepository
+	client *redis.Client
+	ttl    time.Duration
+	mapper mappers.CartMapper
+	// loads makes concurrent misses on the same key wait for one load instead of all hitting MySQL
+	loads singleflight.Group
+
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	sharedLoads atomic.Uint64
+	errors      atomic.Uint64
+}
+
+func NewCachedCartRepository(repository output.CartRepository, client *redis.Client, ttl time.Duration) *CachedCartRepository {
+	return &CachedCartRepository{
+		CartRepository: repository,
+		client:         client,
+		ttl:            ttl,
+	}
+}
+
+func (r *CachedCartRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Cart, error) {
+	return r.read(ctx, cartCacheByIDPrefix+id.String(), func(ctx context.Context) (*domain.Cart, error) {
+		return r.CartRepository.GetById(ctx, id)
+	})
+}
+
+func (r *CachedCartRepository) GetByUserID(ctx context.Context, userId uuid.UUID) (*domain.Cart, error) {
+	return r.read(ctx, cartCacheByUserPrefix+userId.String(), func(ctx context.Context) (*domain.Cart, error) {
+		return r.CartRepository.GetByUserID(ctx, userId)
+	})
+}
+
+func (r *CachedCartRepository) CreateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
+	r.invalidate(ctx, cart.ID, cart.UserID)
+	return r.CartRepository.CreateCart(ctx, cart)
+}
+
+func (r *CachedCartRepository) UpdateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
+	r.invalidate(ctx, cart.ID, cart.UserID)
+	return r.CartRepository.UpdateCart(ctx, cart)
+}
+
+func (r *CachedCartRepository) DeleteCart(ctx context.Context, userId uuid.UUID) error {
+	cartID := uuid.Nil
+	if cart, err := r.CartRepository.GetByUserID(ctx, userId); err == nil {
+		cartID = cart.ID
+	}
+
+	r.invalidate(ctx, cartID, userId)
+	return r.CartRepository.DeleteCart(ctx, userId)
+}
+
+func (r *CachedCartRepository) Stats() CartCacheStats {
+	return CartCacheStats{
+		Hits:        r.hits.Load(),
+		Misses:      r.misses.Load(),
+		SharedLoads: r.sharedLoads.Load(),
+		Errors:      r.errors.Load(),
+	}
+}
+
+func (r *CachedCartRepository) read(ctx context.Context, key string, load func(ctx context.Context) (*domain.Cart, error)) (*domain.Cart, error) {
+	if inTransaction(ctx) {
+		return load(ctx)
+	}
+
+	if cart, found := r.get(ctx, key); found {
+		r.hits.Add(1)
+		return cart, nil
+	}
+	r.misses.Add(1)
+
+	// Only the caller that runs the load sets loaded, the others waited on it
+	loaded := false
+	result, err, _ := r.loads.Do(key, func() (interface{}, error) {
+		loaded = true
+
+		// The load is shared by every caller waiting on key, so it must not be cancelled with the first one
+		ctx := context.WithoutCancel(ctx)
+		cart, err := load(ctx)
+		if err == nil && cart.Promotion == nil {
+			r.set(ctx, key, *cart)
+		}
+		return cart, err
+	})
+	if !loaded {
+		r.sharedLoads.Add(1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers mutate the cart they get back, so each one needs its own copy
+	return cloneCart(*result.(*domain.Cart)), nil
+}
+
+func (r *CachedCartRepository) get(ctx context.Context, key string) (*domain.Cart, bool) {
+	payload, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			r.errors.Add(1)
+		}
+		return nil, false
+	}
+
+	var model models.CartModel
+	if err := json.Unmarshal(payload, &model); err != nil {
+		r.errors.Add(1)
+		return nil, false
+	}
+
+	return r.mapper.ModelToDomain(model), true
+}
+
+func (r *CachedCartRepository) set(ctx context.Context, key string, cart domain.Cart) {
+	payload, err := json.Marshal(r.mapper.DomainToModel(cart))
+	if err == nil {
+		err = r.client.Set(ctx, key, payload, r.ttl).Err()
+	}
+	if err != nil {
+		r.errors.Add(1)
+	}
+}
+
+// invalidate drops the keys now and once more after the transaction commits,
+// so a read that refilled them with the old cart in the meantime does not stay cached.
+func (r *CachedCartRepository) invalidate(ctx context.Context, cartID, userID uuid.UUID) {
+	keys := []string{cartCacheByUserPrefix + userID.String()}
+	if cartID != uuid.Nil {
+		keys = append(keys, cartCacheByIDPrefix+cartID.String())
+	}
+
+	del := func() {
+		if err := r.client.Del(context.WithoutCancel(ctx), keys...).Err(); err != nil {
+			r.errors.Add(1)
+			log.Printf("Cart cache: failed to invalidate %v: %v", keys, err)
+		}
+	}
+
+	del()
+	if inTransaction(ctx) {
+		afterCommit(ctx, del)
+	}
+}
+
+func cloneCart(cart domain.Cart) *domain.Cart {
+	cart.Items = append([]domain.CartItem(nil), cart.Items...)
+	cart.SavedItems = append([]domain.CartItem(nil), cart.SavedItems...)
+	if cart.Promotion != nil {
+		promotion := *cart.Promotion
+		cart.Promotion = &promotion
+	}
+	return &cart
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// fakeCartStore is the repository behind the cache. It counts its loads, which wait for release when it is set.
+type fakeCartStore struct {
+	mu      sync.Mutex
+	carts   map[uuid.UUID]domain.Cart
+	loads   int
+	release chan struct{}
+	panics  bool
+}
+
+func newFakeCartStore(carts ...domain.Cart) *fakeCartStore {
+	store := &fakeCartStore{carts: make(map[uuid.UUID]domain.Cart)}
+	for _, cart := range carts {
+		store.carts[cart.UserID] = cart
+	}
+	return store
+}
+
+func (s *fakeCartStore) load(match func(cart domain.Cart) bool) (*domain.Cart, error) {
+	s.mu.Lock()
+	s.loads++
+	release, panics := s.release, s.panics
+	s.mu.Unlock()
+
+	if release != nil {
+		<-release
+	}
+	if panics {
+		panic("load failed")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cart := range s.carts {
+		if match(cart) {
+			return &cart, nil
+		}
+	}
+	return nil, domain.ErrCartNotFound
+}
+
+func (s *fakeCartStore) loadCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loads
+}
+
+func (s *fakeCartStore) GetById(ctx context.Context, id uuid.UUID) (*domain.Cart, error) {
+	return s.load(func(cart domain.Cart) bool { return cart.ID == id })
+}
+
+func (s *fakeCartStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Cart, error) {
+	return s.load(func(cart domain.Cart) bool { return cart.UserID == userID })
+}
+
+func (s *fakeCartStore) CreateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.carts[cart.UserID] = cart
+	return &cart, nil
+}
+
+func (s *fakeCartStore) UpdateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
+	return s.CreateCart(ctx, cart)
+}
+
+func (s *fakeCartStore) DeleteCart(ctx context.Context, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.carts, userID)
+	return nil
+}
+
+func (s *fakeCartStore) GetAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]domain.Cart, error) {
+	return nil, nil
+}
+
+func (s *fakeCartStore) MarkAbandonedNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	return false, nil
+}
+
+func newTestCachedCartRepository(t *testing.T, store *fakeCartStore) (*CachedCartRepository, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewCachedCartRepository(store, client, time.Minute), server
+}
+
+func newCachedTestCart() domain.Cart {
+	cart := domain.NewCart(uuid.New())
+	cart.Items = append(cart.Items, domain.NewCartItem(uuid.New(), "book", money.New(1999, "USD"), 1, money.Zero("USD")))
+	return *cart
+}
+
+func TestCachedCartRepositoryReadThrough(t *testing.T) {
+	ctx := context.Background()
+	cart := newCachedTestCart()
+	store := newFakeCartStore(cart)
+	repository, server := newTestCachedCartRepository(t, store)
+
+	reads := []struct {
+		name      string
+		read      func() (*domain.Cart, error)
+		wantLoads int
+	}{
+		{name: "miss by user", read: func() (*domain.Cart, error) { return repository.GetByUserID(ctx, cart.UserID) }, wantLoads: 1},
+		{name: "hit by user", read: func() (*domain.Cart, error) { return repository.GetByUserID(ctx, cart.UserID) }, wantLoads: 1},
+		{name: "miss by id", read: func() (*domain.Cart, error) { return repository.GetById(ctx, cart.ID) }, wantLoads: 2},
+		{name: "hit by id", read: func() (*domain.Cart, error) { return repository.GetById(ctx, cart.ID) }, wantLoads: 2},
+	}
+
+	for _, read := range reads {
+		got, err := read.read()
+		if err != nil {
+			t.Fatalf("%s: %v", read.name, err)
+		}
+		if got.ID != cart.ID || len(got.Items) != 1 || !got.Items[0].UnitPrice.Equals(money.New(1999, "USD")) {
+			t.Errorf("%s: got %+v, want %+v", read.name, got, cart)
+		}
+		if loads := store.loadCount(); loads != read.wantLoads {
+			t.Errorf("%s: got %d loads, want %d", read.name, loads, read.wantLoads)
+		}
+	}
+
+	if stats := repository.Stats(); stats.Hits != 2 || stats.Misses != 2 || stats.Errors != 0 {
+		t.Errorf("got stats %+v, want 2 hits and 2 misses", stats)
+	}
+	for _, key := range []string{cartCacheByUserPrefix + cart.UserID.String(), cartCacheByIDPrefix + cart.ID.String()} {
+		if !server.Exists(key) {
+			t.Errorf("%s is not cached", key)
+		}
+	}
+}
+
+func TestCachedCartRepositoryInvalidates(t *testing.T) {
+	tests := []struct {
+		name       string
+		write      func(repository *CachedCartRepository, cart domain.Cart) error
+		wantFrozen bool
+		wantErr    error
+	}{
+		{name: "UpdateCart", write: func(repository *CachedCartRepository, cart domain.Cart) error {
+			cart.CheckoutFrozen = true
+			_, err := repository.UpdateCart(context.Background(), cart)
+			return err
+		}, wantFrozen: true},
+		{name: "DeleteCart", write: func(repository *CachedCartRepository, cart domain.Cart) error {
+			return repository.DeleteCart(context.Background(), cart.UserID)
+		}, wantErr: domain.ErrCartNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cart := newCachedTestCart()
+			store := newFakeCartStore(cart)
+			repository, server := newTestCachedCartRepository(t, store)
+
+			if _, err := repository.GetByUserID(ctx, cart.UserID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repository.GetById(ctx, cart.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.write(repository, cart); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, key := range []string{cartCacheByUserPrefix + cart.UserID.String(), cartCacheByIDPrefix + cart.ID.String()} {
+				if server.Exists(key) {
+					t.Errorf("%s is still cached", key)
+				}
+			}
+
+			// The next read goes back to the store and sees the write
+			loads := store.loadCount()
+			got, err := repository.GetByUserID(ctx, cart.UserID)
+			if store.loadCount() != loads+1 {
+				t.Errorf("read after the write was served from the cache")
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.CheckoutFrozen != tt.wantFrozen {
+				t.Errorf("got checkout frozen %v, want the written cart", got.CheckoutFrozen)
+			}
+		})
+	}
+}
+
+func TestCachedCartRepositorySkipsPromotionCarts(t *testing.T) {
+	ctx := context.Background()
+	cart := newCachedTestCart()
+	cart.Promotion = &domain.Promotion{ID: uuid.New(), Code: "TEN", Type: domain.PercentageOff, Percentage: 10}
+	store := newFakeCartStore(cart)
+	repository, server := newTestCachedCartRepository(t, store)
+
+	for i := 0; i < 2; i++ {
+		got, err := repository.GetByUserID(ctx, cart.UserID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Promotion == nil || got.Promotion.Code != "TEN" {
+			t.Errorf("got promotion %+v, want TEN", got.Promotion)
+		}
+	}
+
+	if loads := store.loadCount(); loads != 2 {
+		t.Errorf("got %d loads, want every read of a promotion cart to load it", loads)
+	}
+	if server.Exists(cartCacheByUserPrefix + cart.UserID.String()) {
+		t.Error("promotion cart was cached")
+	}
+}
+
+func TestCachedCartRepositorySharesConcurrentMisses(t *testing.T) {
+	const callers = 5
+
+	ctx := context.Background()
+	cart := newCachedTestCart()
+	store := newFakeCartStore(cart)
+	store.release = make(chan struct{})
+	repository, _ := newTestCachedCartRepository(t, store)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	carts := make(chan *domain.Cart, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := repository.GetByUserID(ctx, cart.UserID)
+			errs <- err
+			carts <- got
+		}()
+	}
+
+	// Every caller has missed before the load is let through
+	deadline := time.Now().Add(2 * time.Second)
+	for repository.Stats().Misses < callers {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d misses, want %d", repository.Stats().Misses, callers)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(store.release)
+	wg.Wait()
+	close(errs)
+	close(carts)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each caller gets its own copy of the cart
+	seen := make(map[*domain.Cart]bool)
+	for got := range carts {
+		if got.ID != cart.ID || seen[got] {
+			t.Errorf("got %p %+v, want a copy of %s per caller", got, got, cart.ID)
+		}
+		seen[got] = true
+	}
+
+	if loads := store.loadCount(); loads != 1 {
+		t.Errorf("got %d loads, want the concurrent misses to share 1", loads)
+	}
+	if shared := repository.Stats().SharedLoads; shared != callers-1 {
+		t.Errorf("got %d shared loads, want %d", shared, callers-1)
+	}
+}
+
+func TestCachedCartRepositoryRecoversFromPanickingLoad(t *testing.T) {
+	ctx := context.Background()
+	cart := newCachedTestCart()
+	store := newFakeCartStore(cart)
+	store.panics = true
+	repository, _ := newTestCachedCartRepository(t, store)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("load panic was swallowed")
+			}
+		}()
+		repository.GetByUserID(ctx, cart.UserID)
+	}()
+
+	// The key is free again, the next read loads instead of waiting forever on the failed one
+	store.mu.Lock()
+	store.panics = false
+	store.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := repository.GetByUserID(ctx, cart.UserID)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("read after a panicking load is blocked")
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const eventBatchSize = 100
+
+type CartEventRepository struct {
+	db     *gorm.DB
+	mapper mappers.CartEventMapper
+}
+
+func NewCartEventRepository(db *gorm.DB) output.CartEventRepository {
+	return &CartEventRepository{db: db}
+}
+
+func (r *CartEventRepository) Append(ctx context.Context, events []domain.CartEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventModels := make([]models.CartEventModel, len(events))
+	for i, event := range events {
+		eventModels[i] = r.mapper.DomainToModel(event)
+	}
+
+	return conn(ctx, r.db).CreateInBatches(&eventModels, eventBatchSize).Error
+}
+
+func (r *CartEventRepository) GetByCartId(ctx context.Context, cartID uuid.UUID, until time.Time) ([]domain.CartEvent, error) {
+	query := conn(ctx, r.db).Where("cart_id = ?", cartID.String())
+	if !until.IsZero() {
+		query = query.Where("occurred_at <= ?", until)
+	}
+
+	var eventModels []models.CartEventModel
+	if err := query.Order("id ASC").Find(&eventModels).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.CartEvent, len(eventModels))
+	for i, model := range eventModels {
+		events[i] = r.mapper.ModelToDomain(model)
+	}
+	return events, nil
+}
+
+func (r *CartEventRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) error {
+	return conn(ctx, r.db).Model(&models.CartEventModel{}).
+		Where("user_id = ?", userID.String()).
+		Update("user_id", uuid.Nil.String()).Error
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const itemBatchSize = 100
+
+type CartItemRepository struct {
+	db *gorm.DB
+}
+
+func NewCartItemRepository(db *gorm.DB) *CartItemRepository {
+	return &CartItemRepository{db: db}
+}
+
+func (r *CartItemRepository) GetItemsByCartId(ctx context.Context, cartId string) ([]models.CartItemModel, error) {
+	var itemModels []models.CartItemModel
+	if err := conn(ctx, r.db).Where("cart_id = ?", cartId).Find(&itemModels).Error; err != nil {
+		return nil, err
+	}
+
+	return itemModels, nil
+}
+
+func (r *CartItemRepository) GetSavedItemsByCartId(ctx context.Context, cartId string) ([]models.SavedItemModel, error) {
+	var savedModels []models.SavedItemModel
+	if err := conn(ctx, r.db).Where("cart_id = ?", cartId).Find(&savedModels).Error; err != nil {
+		return nil, err
+	}
+
+	return savedModels, nil
+}
+
+// SyncItems makes the stored items of the cart match items.
+// It diffs items against the stored rows by item id, deletes the missing ones in one statement and
+// inserts or updates the new and changed ones in batched upserts. Everything runs in one transaction.
+func (r *CartItemRepository) SyncItems(ctx context.Context, cartId string, items []models.CartItemModel) error {
+	return r.syncItems(ctx, models.CartItemModel{}.TableName(), cartId, items)
+}
+
+// SyncSavedItems does what SyncItems does for the saved for later list.
+func (r *CartItemRepository) SyncSavedItems(ctx context.Context, cartId string, savedItems []models.SavedItemModel) error {
+	items := make([]models.CartItemModel, len(savedItems))
+	for i, saved := range savedItems {
+		items[i] = models.CartItemModel(saved)
+	}
+	return r.syncItems(ctx, models.SavedItemModel{}.TableName(), cartId, items)
+}
+
+// syncItems works on table, cart items and saved items share the same columns.
+func (r *CartItemRepository) syncItems(ctx context.Context, table string, cartId string, items []models.CartItemModel) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var storedItems []models.CartItemModel
+		if err := tx.Table(table).Where("cart_id = ?", cartId).Find(&storedItems).Error; err != nil {
+			return err
+		}
+
+		toDelete, toUpsert := diffItems(cartId, storedItems, items)
+
+		if len(toDelete) > 0 {
+			if err := tx.Table(table).Where("cart_id = ? AND id IN ?", cartId, toDelete).
+				Delete(&models.CartItemModel{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(toUpsert) > 0 {
+			if err := tx.Table(table).Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"product_type", "name", "unit_price_amount", "quantity", "discount_amount", "currency",
+				}),
+			}).CreateInBatches(&toUpsert, itemBatchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// diffItems returns the ids of the stored rows that are no longer wanted and the rows to insert or update.
+// Unchanged rows are left out so they are not written again.
+func diffItems(cartId string, storedItems, items []models.CartItemModel) ([]string, []models.CartItemModel) {
+	stored := make(map[string]models.CartItemModel, len(storedItems))
+	for _, item := range storedItems {
+		stored[item.ID] = item
+	}
+
+	wanted := make(map[string]struct{}, len(items))
+	toUpsert := make([]models.CartItemModel, 0, len(items))
+	for _, item := range items {
+		item.CartID = cartId
+		wanted[item.ID] = struct{}{}
+
+		if existing, found := stored[item.ID]; found && sameItem(existing, item) {
+			continue
+		}
+		toUpsert = append(toUpsert, item)
+	}
+
+	toDelete := make([]string, 0)
+	for _, item := range storedItems {
+		if _, found := wanted[item.ID]; !found {
+			toDelete = append(toDelete, item.ID)
+		}
+	}
+
+	return toDelete, toUpsert
+}
+
+func sameItem(a, b models.CartItemModel) bool {
+	return a.ProductType == b.ProductType &&
+		a.Name == b.Name &&
+		a.UnitPriceAmount == b.UnitPriceAmount &&
+		a.Quantity == b.Quantity &&
+		a.DiscountAmount == b.DiscountAmount &&
+		a.Currency == b.Currency
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens a private in-memory SQLite database with the cart, cart event, outbox and item tables.
+// SQLite understands the ON CONFLICT upsert GORM writes, like MySQL does with ON DUPLICATE KEY.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.CartModel{}, &models.CartEventModel{}, &models.OutboxEventModel{}); err != nil {
+		t.Fatal(err)
+	}
+	// SQLite index names are global, unlike MySQL ones, so the cart_items index is renamed
+	// before the saved items table creates its own idx_cart_product.
+	if err := db.AutoMigrate(&models.CartItemModel{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("DROP INDEX idx_cart_product").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("CREATE INDEX idx_cart_items_cart_product ON cart_items (cart_id, product_id)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.SavedItemModel{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newTestItem(cartId string, name string) models.CartItemModel {
+	return models.CartItemModel{
+		ID:              uuid.NewString(),
+		CartID:          cartId,
+		ProductID:       uuid.NewString(),
+		ProductType:     "PHYSICAL",
+		Name:            name,
+		UnitPriceAmount: 1000,
+		Quantity:        1,
+		Currency:        "USD",
+		AddedAt:         time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func sortedByName(items []models.CartItemModel) []models.CartItemModel {
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+func TestSyncItems(t *testing.T) {
+	cartId := uuid.NewString()
+	book := newTestItem(cartId, "book")
+	mug := newTestItem(cartId, "mug")
+	pen := newTestItem(cartId, "pen")
+
+	course := book
+	course.ProductType = "COURSE"
+
+	twoMugs := mug
+	twoMugs.Quantity = 2
+
+	discounted := mug
+	discounted.DiscountAmount = 150
+
+	tests := []struct {
+		name  string
+		items []models.CartItemModel
+	}{
+		{name: "inserts new items", items: []models.CartItemModel{book, mug}},
+		{name: "updates the product type", items: []models.CartItemModel{course, mug}},
+		{name: "updates the quantity", items: []models.CartItemModel{course, twoMugs}},
+		{name: "updates the discount", items: []models.CartItemModel{course, discounted}},
+		{name: "adds and deletes in one sync", items: []models.CartItemModel{discounted, pen}},
+		{name: "empties the cart", items: []models.CartItemModel{}},
+	}
+
+	// Every case syncs over the rows the previous one left
+	db := newTestDB(t)
+	repository := NewCartItemRepository(db)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repository.SyncItems(context.Background(), cartId, tt.items); err != nil {
+				t.Fatalf("SyncItems: %v", err)
+			}
+
+			stored, err := repository.GetItemsByCartId(context.Background(), cartId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := sortedByName(append([]models.CartItemModel{}, tt.items...))
+			if !reflect.DeepEqual(sortedByName(stored), want) {
+				t.Errorf("got %+v, want %+v", stored, want)
+			}
+		})
+	}
+}
+
+func TestSyncItemsLeavesOtherCarts(t *testing.T) {
+	db := newTestDB(t)
+	repository := NewCartItemRepository(db)
+
+	cartId, otherCartId := uuid.NewString(), uuid.NewString()
+	other := newTestItem(otherCartId, "other")
+	if err := repository.SyncItems(context.Background(), otherCartId, []models.CartItemModel{other}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.SyncItems(context.Background(), cartId, []models.CartItemModel{newTestItem(cartId, "book")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.SyncItems(context.Background(), cartId, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := repository.GetItemsByCartId(context.Background(), otherCartId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored, []models.CartItemModel{other}) {
+		t.Errorf("got %+v, want the other cart untouched", stored)
+	}
+}
+
+func TestSyncSavedItems(t *testing.T) {
+	db := newTestDB(t)
+	repository := NewCartItemRepository(db)
+
+	cartId := uuid.NewString()
+	saved := models.SavedItemModel(newTestItem(cartId, "course"))
+	if err := repository.SyncSavedItems(context.Background(), cartId, []models.SavedItemModel{saved}); err != nil {
+		t.Fatal(err)
+	}
+
+	saved.ProductType = "COURSE"
+	if err := repository.SyncSavedItems(context.Background(), cartId, []models.SavedItemModel{saved}); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := repository.GetSavedItemsByCartId(context.Background(), cartId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored, []models.SavedItemModel{saved}) {
+		t.Errorf("got %+v, want %+v", stored, saved)
+	}
+
+	items, err := repository.GetItemsByCartId(context.Background(), cartId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("saved items leaked into the cart: %+v", items)
+	}
+}
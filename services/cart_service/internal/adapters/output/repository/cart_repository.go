@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
@@ -11,23 +13,32 @@ import (
 	"gorm.io/gorm"
 )
 
+// CartRepository appends the events recorded on a cart to the eventRepository in the transaction that saves it.
 type CartRepository struct {
-	db             *gorm.DB
-	itemRepository CartItemRepository
-	mapper         mappers.CartMapper
+	db              *gorm.DB
+	itemRepository  CartItemRepository
+	eventRepository output.CartEventRepository
+	mapper          mappers.CartMapper
 }
 
-func NewCartRepository(db *gorm.DB, itemRepository CartItemRepository) output.CartRepository {
+func NewCartRepository(db *gorm.DB, itemRepository CartItemRepository, eventRepository output.CartEventRepository) output.CartRepository {
 	return &CartRepository{
-		db:             db,
-		itemRepository: itemRepository,
+		db:              db,
+		itemRepository:  itemRepository,
+		eventRepository: eventRepository,
 	}
 }
 
 func (r *CartRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Cart, error) {
 	var cartModel models.CartModel
 
-	if err := r.db.WithContext(ctx).First(&cartModel, id).Error; err != nil {
+	if err := conn(ctx, r.db).
+		Preload("Promotion").
+		Where("id = ?", id.String()).
+		First(&cartModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCartNotFound
+		}
 		return nil, err
 	}
 
@@ -38,9 +49,13 @@ func (r *CartRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Car
 
 func (r *CartRepository) GetByUserID(ctx context.Context, userId uuid.UUID) (*domain.Cart, error) {
 	var cartModel models.CartModel
-	if err := r.db.WithContext(ctx).
+	if err := conn(ctx, r.db).
+		Preload("Promotion").
 		Where("user_id = ?", userId).
 		First(&cartModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCartNotFound
+		}
 		return nil, err
 	}
 
@@ -52,44 +67,137 @@ func (r *CartRepository) GetByUserID(ctx context.Context, userId uuid.UUID) (*do
 func (r *CartRepository) CreateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
 	cartModel := r.mapper.DomainToModel(cart)
 
-	if err := r.db.WithContext(ctx).Create(&cartModel).Error; err != nil {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(ctx, txKey{}, tx)
+
+		if err := conn(ctx, r.db).Create(&cartModel).Error; err != nil {
+			return err
+		}
+
+		return r.eventRepository.Append(ctx, cart.Events)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return r.mapper.ModelToDomain(*cartModel), nil
 }
 
+// UpdateCart saves the cart only if it is still at cart.Version and bumps the version.
+// Otherwise it returns domain.ErrConcurrentModification and nothing is written.
 func (r *CartRepository) UpdateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
 	cartModel := r.mapper.DomainToModel(cart)
+	cartModel.Version = cart.Version + 1
 
-	if err := r.db.WithContext(ctx).
-		Where("id = ?", cartModel.ID).
-		Updates(cartModel).Error; err != nil {
-		return nil, err
-	}
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(ctx, txKey{}, tx)
+
+		result := conn(ctx, r.db).
+			Model(&models.CartModel{}).
+			Where("id = ? AND version = ?", cartModel.ID, cart.Version).
+			Update("version", gorm.Expr("version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrConcurrentModification
+		}
 
-	if err := r.updateItems(ctx, cart); err != nil {
+		// A map is used so a removed coupon (nil promotion_id) is written too
+		if err := conn(ctx, r.db).
+			Model(&models.CartModel{}).
+			Where("id = ?", cartModel.ID).
+			Updates(map[string]interface{}{
+				"promotion_id":     cartModel.PromotionID,
+				"display_currency": cartModel.DisplayCurrency,
+				"checkout_frozen":  cartModel.CheckoutFrozen,
+				"updated_at":       cartModel.UpdatedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := r.itemRepository.SyncItems(ctx, cartModel.ID, cartModel.Items); err != nil {
+			return err
+		}
+
+		if err := r.itemRepository.SyncSavedItems(ctx, cartModel.ID, cartModel.SavedItems); err != nil {
+			return err
+		}
+
+		return r.eventRepository.Append(ctx, cart.Events)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	r.appendItems(ctx, cartModel)
 
-	return r.mapper.ModelToDomain(*cartModel), nil
+	cartUpdated := r.mapper.ModelToDomain(*cartModel)
+	cartUpdated.Promotion = cart.Promotion
+	return cartUpdated, nil
 }
 
-func (r *CartRepository) DeleteCart(ctx context.Context, userId uuid.UUID) error {
-	var cartModel models.CartModel
-	err := r.db.WithContext(ctx).Where("user_id = ?", userId).First(&cartModel).Error
-	if err != nil {
-		return err
+// abandonedScope matches non-empty carts idle since idleSince whose idle period was not notified yet.
+// Any update moves updated_at past abandoned_notified_at, which starts a new idle period.
+func abandonedScope(idleSince time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Where("updated_at <= ?", idleSince).
+			Where("abandoned_notified_at IS NULL OR abandoned_notified_at < updated_at").
+			Where("EXISTS (SELECT 1 FROM cart_items WHERE cart_items.cart_id = cart.id)")
 	}
+}
 
-	err = r.db.WithContext(ctx).Delete(&cartModel).Error
-	if err != nil {
-		return err
+func (r *CartRepository) GetAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]domain.Cart, error) {
+	var cartModels []models.CartModel
+	if err := conn(ctx, r.db).
+		Scopes(abandonedScope(idleSince)).
+		Order("updated_at").
+		Limit(limit).
+		Find(&cartModels).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	carts := make([]domain.Cart, len(cartModels))
+	for i := range cartModels {
+		r.appendItems(ctx, &cartModels[i])
+		carts[i] = *r.mapper.ModelToDomain(cartModels[i])
+	}
+
+	return carts, nil
+}
+
+// MarkAbandonedNotified uses UpdateColumn so updated_at and the version are left alone.
+func (r *CartRepository) MarkAbandonedNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := conn(ctx, r.db).
+		Model(&models.CartModel{}).
+		Where("id = ?", id.String()).
+		Where("abandoned_notified_at IS NULL OR abandoned_notified_at < updated_at").
+		UpdateColumn("abandoned_notified_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteCart records the remaining lines as cleared before deleting the cart, its events are kept.
+func (r *CartRepository) DeleteCart(ctx context.Context, userId uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(ctx, txKey{}, tx)
+
+		cart, err := r.GetByUserID(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		cart.Clear()
+		if err := r.eventRepository.Append(ctx, cart.Events); err != nil {
+			return err
+		}
+
+		return conn(ctx, r.db).Delete(&models.CartModel{}, "id = ?", cart.ID.String()).Error
+	})
 }
 
 func (r *CartRepository) appendItems(ctx context.Context, cartModel *models.CartModel) error {
@@ -99,23 +207,12 @@ func (r *CartRepository) appendItems(ctx context.Context, cartModel *models.Cart
 	}
 
 	cartModel.Items = cartItemsModel
-	return nil
-}
-
-func (r *CartRepository) updateItems(ctx context.Context, cart domain.Cart) error {
-	// Check if are some candidates to be deleted
-	r.itemRepository.DeleteItems(ctx, cart)
 
-	// Create or Update depding in each item case
-	for _, item := range cart.Items {
-		var existingItem models.CartItemModel
-		_, err := r.itemRepository.GetItemByCartAndProduct(ctx, item.ID.String(), item.ProductID.String())
-		if err != nil {
-			r.itemRepository.CreateItem(ctx, item)
-		} else {
-			r.itemRepository.UpdateItem(ctx, item, existingItem)
-		}
+	savedItemsModel, err := r.itemRepository.GetSavedItemsByCartId(ctx, cartModel.ID)
+	if err != nil {
+		return nil
 	}
 
+	cartModel.SavedItems = savedItemsModel
 	return nil
 }
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func newTestCartRepository(db *gorm.DB) *CartRepository {
+	return NewCartRepository(db, *NewCartItemRepository(db), NewCartEventRepository(db)).(*CartRepository)
+}
+
+// newTestCart stores a new cart holding one item per name.
+func newTestCart(t *testing.T, repository *CartRepository, names ...string) *domain.Cart {
+	t.Helper()
+
+	cart := domain.NewCart(uuid.New())
+	for _, name := range names {
+		if err := cart.AddItem(domain.NewCartItem(uuid.New(), name, money.New(1000, "USD"), 1, money.Zero("USD"))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	created, err := repository.CreateCart(context.Background(), *cart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created
+}
+
+func abandonedIDs(t *testing.T, repository *CartRepository, idleSince time.Time) []uuid.UUID {
+	t.Helper()
+
+	carts, err := repository.GetAbandoned(context.Background(), idleSince, 10)
+	if err != nil {
+		t.Fatalf("GetAbandoned: %v", err)
+	}
+
+	ids := make([]uuid.UUID, len(carts))
+	for i, cart := range carts {
+		ids[i] = cart.ID
+	}
+	return ids
+}
+
+func TestAbandonedCarts(t *testing.T) {
+	ctx := context.Background()
+	repository := newTestCartRepository(newTestDB(t))
+
+	before := time.Now()
+	idle := newTestCart(t, repository, "book")
+	newTestCart(t, repository)
+
+	if ids := abandonedIDs(t, repository, before); len(ids) != 0 {
+		t.Errorf("got abandoned carts %v, want none active before they were idle", ids)
+	}
+
+	// The empty cart is never abandoned
+	idleSince := time.Now()
+	if ids := abandonedIDs(t, repository, idleSince); len(ids) != 1 || ids[0] != idle.ID {
+		t.Fatalf("got abandoned carts %v, want only %s", ids, idle.ID)
+	}
+
+	marked, err := repository.MarkAbandonedNotified(ctx, idle.ID, time.Now())
+	if err != nil {
+		t.Fatalf("MarkAbandonedNotified: %v", err)
+	}
+	if !marked {
+		t.Fatal("cart was not marked as notified")
+	}
+
+	if ids := abandonedIDs(t, repository, idleSince); len(ids) != 0 {
+		t.Errorf("got abandoned carts %v, want the notified cart left out", ids)
+	}
+	if marked, err := repository.MarkAbandonedNotified(ctx, idle.ID, time.Now()); err != nil || marked {
+		t.Errorf("marked the same idle period twice: %v, %v", marked, err)
+	}
+
+	// A change starts a new idle period
+	stored, err := repository.GetById(ctx, idle.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stored.AddItem(domain.NewCartItem(uuid.New(), "mug", money.New(800, "USD"), 1, money.Zero("USD"))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repository.UpdateCart(ctx, *stored); err != nil {
+		t.Fatalf("UpdateCart: %v", err)
+	}
+
+	if ids := abandonedIDs(t, repository, time.Now()); len(ids) != 1 || ids[0] != idle.ID {
+		t.Errorf("got abandoned carts %v, want %s again after it changed", ids, idle.ID)
+	}
+}
+
+func storedItemNames(t *testing.T, repository *CartRepository, id uuid.UUID) []string {
+	t.Helper()
+
+	cart, err := repository.GetById(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, len(cart.Items))
+	for i, item := range cart.Items {
+		names[i] = item.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestUpdateCartStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repository := newTestCartRepository(db)
+	outbox := NewOutboxRepository(db)
+	transactions := NewTransactionManager(db)
+
+	created := newTestCart(t, repository, "book")
+
+	// Two requests read the same version, the first one saves
+	first, err := repository.GetById(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, err := repository.GetById(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := first.AddItem(domain.NewCartItem(uuid.New(), "mug", money.New(800, "USD"), 1, money.Zero("USD"))); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := repository.UpdateCart(ctx, *first)
+	if err != nil {
+		t.Fatalf("UpdateCart: %v", err)
+	}
+
+	// The second one saves with an outbox event in the same transaction, like a checkout does
+	if err := stale.AddItem(domain.NewCartItem(uuid.New(), "pen", money.New(250, "USD"), 1, money.Zero("USD"))); err != nil {
+		t.Fatal(err)
+	}
+	err = transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := outbox.Save(ctx, domain.NewOutboxEvent(stale.ID, domain.OrderCreatedEvent, []byte("{}"))); err != nil {
+			return err
+		}
+		_, err := repository.UpdateCart(ctx, *stale)
+		return err
+	})
+	if !errors.Is(err, domain.ErrConcurrentModification) {
+		t.Fatalf("got error %v, want ErrConcurrentModification", err)
+	}
+
+	if names := storedItemNames(t, repository, created.ID); len(names) != 2 || names[0] != "book" || names[1] != "mug" {
+		t.Errorf("got items %v, want the book and mug of the first save", names)
+	}
+
+	var outboxRows int64
+	if err := db.Model(&models.OutboxEventModel{}).Count(&outboxRows).Error; err != nil {
+		t.Fatal(err)
+	}
+	if outboxRows != 0 {
+		t.Errorf("got %d outbox rows, want the event rolled back with the cart", outboxRows)
+	}
+
+	stored, err := repository.GetById(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version != saved.Version {
+		t.Errorf("got version %d, want %d of the first save", stored.Version, saved.Version)
+	}
+}
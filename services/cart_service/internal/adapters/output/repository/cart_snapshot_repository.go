@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CartSnapshotRepository struct {
+	db     *gorm.DB
+	mapper mappers.CartSnapshotMapper
+}
+
+func NewCartSnapshotRepository(db *gorm.DB) output.CartSnapshotRepository {
+	return &CartSnapshotRepository{db: db}
+}
+
+func (r *CartSnapshotRepository) CreateSnapshot(ctx context.Context, snapshot domain.CartSnapshot) error {
+	return conn(ctx, r.db).Create(r.mapper.DomainToModel(snapshot)).Error
+}
+
+func (r *CartSnapshotRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.CartSnapshot, error) {
+	var snapshotModel models.CartSnapshotModel
+	err := conn(ctx, r.db).
+		Preload("Items").
+		Where("token_hash = ?", tokenHash).
+		First(&snapshotModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.mapper.ModelToDomain(snapshotModel), nil
+}
+
+func (r *CartSnapshotRepository) DeleteByOwner(ctx context.Context, ownerID uuid.UUID) error {
+	return conn(ctx, r.db).Where("owner_id = ?", ownerID.String()).Delete(&models.CartSnapshotModel{}).Error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+type CheckoutRepository struct {
+	db     *gorm.DB
+	mapper mappers.CheckoutMapper
+}
+
+func NewCheckoutRepository(db *gorm.DB) output.CheckoutRepository {
+	return &CheckoutRepository{db: db}
+}
+
+func (r *CheckoutRepository) CreateCheckout(ctx context.Context, checkout domain.Checkout) error {
+	checkoutModel := r.mapper.DomainToModel(checkout)
+
+	if err := conn(ctx, r.db).Create(checkoutModel).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const guestCartKeyPrefix = "guest_cart:"
+
+type RedisGuestCartRepository struct {
+	client *redis.Client
+	mapper mappers.CartMapper
+}
+
+func NewGuestCartRepository(client *redis.Client) output.GuestCartRepository {
+	return &RedisGuestCartRepository{client: client}
+}
+
+func (r *RedisGuestCartRepository) CreateCart(ctx context.Context, cart domain.Cart, ttl time.Duration) error {
+	payload, err := json.Marshal(r.mapper.DomainToGuestModel(cart))
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, guestCartKey(cart.ID), payload, ttl).Err()
+}
+
+// UpdateCart keeps the expiry set on creation, a guest cart does not live longer because it is used.
+func (r *RedisGuestCartRepository) UpdateCart(ctx context.Context, cart domain.Cart) error {
+	payload, err := json.Marshal(r.mapper.DomainToGuestModel(cart))
+	if err != nil {
+		return err
+	}
+
+	updated, err := r.client.SetArgs(ctx, guestCartKey(cart.ID), payload, redis.SetArgs{
+		Mode:    "XX",
+		KeepTTL: true,
+	}).Result()
+	if errors.Is(err, redis.Nil) || (err == nil && updated != "OK") {
+		return domain.ErrGuestCartNotFound
+	}
+
+	return err
+}
+
+func (r *RedisGuestCartRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Cart, error) {
+	payload, err := r.client.Get(ctx, guestCartKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrGuestCartNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var model models.GuestCartModel
+	if err := json.Unmarshal(payload, &model); err != nil {
+		return nil, err
+	}
+
+	return r.mapper.GuestModelToDomain(model), nil
+}
+
+func (r *RedisGuestCartRepository) DeleteCart(ctx context.Context, id uuid.UUID) error {
+	return r.client.Del(ctx, guestCartKey(id)).Err()
+}
+
+func guestCartKey(id uuid.UUID) string {
+	return guestCartKeyPrefix + id.String()
+}
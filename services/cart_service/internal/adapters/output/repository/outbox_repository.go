@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	outboxStatusPending = "PENDING"
+	outboxStatusSent    = "SENT"
+	outboxStatusFailed  = "FAILED"
+)
+
+type OutboxRepository struct {
+	db     *gorm.DB
+	mapper mappers.OutboxMapper
+}
+
+func NewOutboxRepository(db *gorm.DB) output.OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) Save(ctx context.Context, event domain.OutboxEvent) error {
+	return conn(ctx, r.db).Create(r.mapper.DomainToModel(event, outboxStatusPending)).Error
+}
+
+func (r *OutboxRepository) GetPending(ctx context.Context, limit int) ([]domain.OutboxEvent, error) {
+	var eventModels []models.OutboxEventModel
+	if err := conn(ctx, r.db).
+		Where("status = ?", outboxStatusPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&eventModels).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.OutboxEvent, len(eventModels))
+	for i, model := range eventModels {
+		events[i] = r.mapper.ModelToDomain(model)
+	}
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&models.OutboxEventModel{}).
+		Where("id = ?", id.String()).
+		Updates(map[string]interface{}{
+			"status":  outboxStatusSent,
+			"sent_at": time.Now(),
+		}).Error
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, cause error) error {
+	return r.recordFailure(ctx, id, cause, outboxStatusPending)
+}
+
+// MarkDead moves the event to FAILED, it is kept for inspection but never relayed again.
+func (r *OutboxRepository) MarkDead(ctx context.Context, id uuid.UUID, cause error) error {
+	return r.recordFailure(ctx, id, cause, outboxStatusFailed)
+}
+
+func (r *OutboxRepository) recordFailure(ctx context.Context, id uuid.UUID, cause error, status string) error {
+	lastError := cause.Error()
+	if len(lastError) > 512 {
+		lastError = lastError[:512]
+	}
+
+	return conn(ctx, r.db).
+		Model(&models.OutboxEventModel{}).
+		Where("id = ?", id.String()).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": lastError,
+		}).Error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProcessedEventRepository struct {
+	db     *gorm.DB
+	mapper mappers.UserEventMapper
+}
+
+func NewProcessedEventRepository(db *gorm.DB) output.ProcessedEventRepository {
+	return &ProcessedEventRepository{db: db}
+}
+
+// MarkProcessed relies on the event id primary key: a second insert of the same event affects no row.
+// Inside a transaction a concurrent delivery of the event waits for the first one to commit or roll back.
+func (r *ProcessedEventRepository) MarkProcessed(ctx context.Context, event domain.UserEvent) (bool, error) {
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(r.mapper.DomainToProcessedModel(event, time.Now()))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ProcessedEventRepository) HasProcessed(ctx context.Context, userID uuid.UUID, eventType domain.UserEventType) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&models.ProcessedUserEventModel{}).
+		Where("user_id = ? AND event_type = ?", userID.String(), string(eventType)).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository struct {
+	db     *gorm.DB
+	mapper mappers.PromotionMapper
+}
+
+func NewPromotionRepository(db *gorm.DB) output.PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+func (r *PromotionRepository) CreatePromotion(ctx context.Context, promotion domain.Promotion) (*domain.Promotion, error) {
+	promotionModel := r.mapper.DomainToModel(promotion)
+	if err := conn(ctx, r.db).Create(promotionModel).Error; err != nil {
+		return nil, err
+	}
+
+	return r.mapper.ModelToDomain(*promotionModel), nil
+}
+
+func (r *PromotionRepository) UpdatePromotion(ctx context.Context, promotion domain.Promotion) (*domain.Promotion, error) {
+	promotionModel := r.mapper.DomainToModel(promotion)
+	if err := conn(ctx, r.db).Save(promotionModel).Error; err != nil {
+		return nil, err
+	}
+
+	return r.mapper.ModelToDomain(*promotionModel), nil
+}
+
+func (r *PromotionRepository) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Where("id = ?", id.String()).Delete(&models.PromotionModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+func (r *PromotionRepository) GetById(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	var promotionModel models.PromotionModel
+	if err := conn(ctx, r.db).Where("id = ?", id.String()).First(&promotionModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+
+	return r.mapper.ModelToDomain(promotionModel), nil
+}
+
+func (r *PromotionRepository) GetByCode(ctx context.Context, code string) (*domain.Promotion, error) {
+	var promotionModel models.PromotionModel
+	if err := conn(ctx, r.db).
+		Where("code = ?", strings.ToUpper(strings.TrimSpace(code))).
+		First(&promotionModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+
+	return r.mapper.ModelToDomain(promotionModel), nil
+}
+
+func (r *PromotionRepository) GetAll(ctx context.Context) ([]domain.Promotion, error) {
+	var promotionModels []models.PromotionModel
+	if err := conn(ctx, r.db).Order("created_at DESC").Find(&promotionModels).Error; err != nil {
+		return nil, err
+	}
+
+	promotions := make([]domain.Promotion, len(promotionModels))
+	for i, model := range promotionModels {
+		promotions[i] = *r.mapper.ModelToDomain(model)
+	}
+	return promotions, nil
+}
+
+func (r *PromotionRepository) CountRedemptions(ctx context.Context, promotionID, userID uuid.UUID) (int, error) {
+	var count int64
+	if err := conn(ctx, r.db).
+		Model(&models.PromotionRedemptionModel{}).
+		Where("promotion_id = ? AND user_id = ?", promotionID.String(), userID.String()).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (r *PromotionRepository) CreateRedemption(ctx context.Context, redemption domain.PromotionRedemption) error {
+	return conn(ctx, r.db).Create(r.mapper.RedemptionToModel(redemption)).Error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txHooksKey struct{}
+
+// txHooks holds the callbacks to run once the outermost transaction commits.
+type txHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+type GormTransactionManager struct {
+	db *gorm.DB
+}
+
+func NewTransactionManager(db *gorm.DB) output.TransactionManager {
+	return &GormTransactionManager{db: db}
+}
+
+func (m *GormTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	hooks, nested := ctx.Value(txHooksKey{}).(*txHooks)
+	if !nested {
+		hooks = &txHooks{}
+		ctx = context.WithValue(ctx, txHooksKey{}, hooks)
+	}
+
+	err := conn(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+	if err == nil && !nested {
+		hooks.mu.Lock()
+		defer hooks.mu.Unlock()
+		for _, hook := range hooks.fns {
+			hook()
+		}
+	}
+
+	return err
+}
+
+// afterCommit runs fn once the transaction bound to ctx commits, or right away when there is none.
+// Nothing runs when the transaction rolls back.
+func afterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(txHooksKey{}).(*txHooks)
+	if !ok || !inTransaction(ctx) {
+		fn()
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.fns = append(hooks.fns, fn)
+}
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
+
+// conn returns the transaction bound to ctx, falling back to db when there is none.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,70 @@
+package tax
+
+// DefaultTaxRules covers the countries whose addresses user_service validates (MX, US and CA).
+// US rates are the state-level sales tax only, local surcharges are not included.
+func DefaultTaxRules() []TaxRule {
+	return []TaxRule{
+		// Mexico
+		{Country: "MX", Name: "IVA", Rate: 16},
+
+		// Canada: GST everywhere, plus PST/QST or the provincial part of HST
+		{Country: "CA", Name: "GST", Rate: 5},
+		{Country: "CA", State: "BC", Name: "PST", Rate: 7},
+		{Country: "CA", State: "MB", Name: "RST", Rate: 7},
+		{Country: "CA", State: "SK", Name: "PST", Rate: 6},
+		{Country: "CA", State: "QC", Name: "QST", Rate: 9.975},
+		{Country: "CA", State: "ON", Name: "HST (provincial)", Rate: 8},
+		{Country: "CA", State: "NB", Name: "HST (provincial)", Rate: 10},
+		{Country: "CA", State: "NL", Name: "HST (provincial)", Rate: 10},
+		{Country: "CA", State: "NS", Name: "HST (provincial)", Rate: 9},
+		{Country: "CA", State: "PE", Name: "HST (provincial)", Rate: 10},
+
+		// United States: state sales tax
+		{Country: "US", State: "AL", Name: "Sales Tax", Rate: 4},
+		{Country: "US", State: "AZ", Name: "Sales Tax", Rate: 5.6},
+		{Country: "US", State: "AR", Name: "Sales Tax", Rate: 6.5},
+		{Country: "US", State: "CA", Name: "Sales Tax", Rate: 7.25},
+		{Country: "US", State: "CO", Name: "Sales Tax", Rate: 2.9},
+		{Country: "US", State: "CT", Name: "Sales Tax", Rate: 6.35},
+		{Country: "US", State: "DC", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "FL", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "GA", Name: "Sales Tax", Rate: 4},
+		{Country: "US", State: "HI", Name: "General Excise Tax", Rate: 4},
+		{Country: "US", State: "ID", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "IL", Name: "Sales Tax", Rate: 6.25},
+		{Country: "US", State: "IN", Name: "Sales Tax", Rate: 7},
+		{Country: "US", State: "IA", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "KS", Name: "Sales Tax", Rate: 6.5},
+		{Country: "US", State: "KY", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "LA", Name: "Sales Tax", Rate: 5},
+		{Country: "US", State: "ME", Name: "Sales Tax", Rate: 5.5},
+		{Country: "US", State: "MD", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "MA", Name: "Sales Tax", Rate: 6.25},
+		{Country: "US", State: "MI", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "MN", Name: "Sales Tax", Rate: 6.875},
+		{Country: "US", State: "MS", Name: "Sales Tax", Rate: 7},
+		{Country: "US", State: "MO", Name: "Sales Tax", Rate: 4.225},
+		{Country: "US", State: "NE", Name: "Sales Tax", Rate: 5.5},
+		{Country: "US", State: "NV", Name: "Sales Tax", Rate: 6.85},
+		{Country: "US", State: "NJ", Name: "Sales Tax", Rate: 6.625},
+		{Country: "US", State: "NM", Name: "Gross Receipts Tax", Rate: 4.875},
+		{Country: "US", State: "NY", Name: "Sales Tax", Rate: 4},
+		{Country: "US", State: "NC", Name: "Sales Tax", Rate: 4.75},
+		{Country: "US", State: "ND", Name: "Sales Tax", Rate: 5},
+		{Country: "US", State: "OH", Name: "Sales Tax", Rate: 5.75},
+		{Country: "US", State: "OK", Name: "Sales Tax", Rate: 4.5},
+		{Country: "US", State: "PA", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "RI", Name: "Sales Tax", Rate: 7},
+		{Country: "US", State: "SC", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "SD", Name: "Sales Tax", Rate: 4.2},
+		{Country: "US", State: "TN", Name: "Sales Tax", Rate: 7},
+		{Country: "US", State: "TX", Name: "Sales Tax", Rate: 6.25},
+		{Country: "US", State: "UT", Name: "Sales Tax", Rate: 6.1},
+		{Country: "US", State: "VT", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "VA", Name: "Sales Tax", Rate: 5.3},
+		{Country: "US", State: "WA", Name: "Sales Tax", Rate: 6.5},
+		{Country: "US", State: "WV", Name: "Sales Tax", Rate: 6},
+		{Country: "US", State: "WI", Name: "Sales Tax", Rate: 5},
+		{Country: "US", State: "WY", Name: "Sales Tax", Rate: 4},
+	}
+}
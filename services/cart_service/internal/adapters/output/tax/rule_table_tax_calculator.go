@@ -0,0 +1,69 @@
+package tax
+
+import (
+	"context"
+	"math"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+)
+
+// TaxRule charges Rate percent on purchases shipped to Country.
+// An empty State applies to the whole country, otherwise only to that subdivision.
+type TaxRule struct {
+	Country string
+	State   string
+	Name    string
+	Rate    float64
+}
+
+type ruleKey struct {
+	country string
+	state   string
+}
+
+type RuleTableTaxCalculator struct {
+	rules map[ruleKey][]TaxRule
+}
+
+func NewRuleTableTaxCalculator(rules []TaxRule) output.TaxCalculator {
+	table := make(map[ruleKey][]TaxRule)
+	for _, rule := range rules {
+		address := domain.NewTaxAddress(rule.Country, rule.State, "")
+		key := ruleKey{country: address.Country, state: address.State}
+		table[key] = append(table[key], rule)
+	}
+
+	return &RuleTableTaxCalculator{rules: table}
+}
+
+// CalculateTax applies the country rules first and then the state rules.
+func (t *RuleTableTaxCalculator) CalculateTax(ctx context.Context, address domain.TaxAddress, taxable money.Money) ([]domain.TaxLine, error) {
+	if !taxable.IsPositive() {
+		return []domain.TaxLine{}, nil
+	}
+
+	rules := append([]TaxRule{}, t.rules[ruleKey{country: address.Country}]...)
+	if address.State != "" {
+		rules = append(rules, t.rules[ruleKey{country: address.Country, state: address.State}]...)
+	}
+
+	lines := make([]domain.TaxLine, len(rules))
+	for i, rule := range rules {
+		lines[i] = domain.TaxLine{
+			Name:   rule.Name,
+			Rate:   rule.Rate,
+			Amount: applyRate(taxable, rule.Rate),
+		}
+	}
+
+	return lines, nil
+}
+
+// applyRate rounds half away from zero to the minor unit.
+// Money.Percent works in basis points, which is not precise enough for rates such as 9.975%.
+func applyRate(amount money.Money, rate float64) money.Money {
+	minor := math.Round(float64(amount.Amount()) * rate / 100)
+	return money.New(int64(minor), amount.Currency())
+}
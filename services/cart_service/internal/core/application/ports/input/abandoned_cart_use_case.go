@@ -0,0 +1,14 @@
+package input
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+type AbandonedCartUseCase interface {
+	// GetAbandonedCarts lists the carts the next run would notify, without notifying them.
+	GetAbandonedCarts(ctx context.Context) ([]dtos.CartAbandonedEvent, error)
+	// NotifyAbandonedCarts emits a CartAbandoned event per abandoned cart and returns how many were emitted.
+	NotifyAbandonedCarts(ctx context.Context) (int, error)
+}
@@ -0,0 +1,15 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type CartHistoryUseCase interface {
+	GetTimeline(ctx context.Context, cartID uuid.UUID) (*dtos.CartTimelineDTO, error)
+	// GetCartAsOf rebuilds the lines the cart had at the given time from its events.
+	GetCartAsOf(ctx context.Context, cartID uuid.UUID, at time.Time) (*dtos.CartDTO, error)
+}
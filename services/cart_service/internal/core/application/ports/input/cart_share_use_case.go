@@ -0,0 +1,16 @@
+package input
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type CartShareUseCase interface {
+	// ShareCart freezes the lines of the user's cart and returns the token to send to the recipient.
+	ShareCart(ctx context.Context, userID uuid.UUID) (*dtos.CartShareDTO, error)
+	GetSharedCart(ctx context.Context, token string) (*dtos.CartSnapshotDTO, error)
+	// ImportSharedCart adds the shared lines to the user's cart with CartUseCase.AddItems.
+	ImportSharedCart(ctx context.Context, token string, userID uuid.UUID) (*dtos.CartDTO, error)
+}
@@ -9,10 +9,22 @@ import (
 
 type CartUseCase interface {
 	CreateCart(ctx context.Context, userID uuid.UUID) error
-	Buy(ctx context.Context, userID uuid.UUID, excludeItemsIDs []*uuid.UUID) error
+	Buy(ctx context.Context, userID uuid.UUID, excludeItemsIDs []*uuid.UUID) (*dtos.PurchaseDetails, error)
+	BuyProduct(ctx context.Context, userID uuid.UUID, insertDTO dtos.CartItemInserDTO) (*dtos.PurchaseDetails, error)
 	AddItems(ctx context.Context, userID uuid.UUID, insertDTO []dtos.CartItemInserDTO) (*dtos.CartDTO, error)
+	UpdateItemQuantity(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, quantity int) (*dtos.CartDTO, error)
 	RemoveItems(ctx context.Context, userID uuid.UUID, itemIDs []uuid.UUID) (*dtos.CartDTO, error)
+	ApplyOperations(ctx context.Context, userID uuid.UUID, request dtos.CartOperationsDTO) (*dtos.CartOperationsResultDTO, error)
+	GetSavedItems(ctx context.Context, userID uuid.UUID) ([]dtos.SavedItemDTO, error)
+	MoveToSaved(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*dtos.CartDTO, error)
+	MoveToCart(ctx context.Context, userID uuid.UUID, savedItemID uuid.UUID) (*dtos.CartDTO, error)
+	RemoveSavedItem(ctx context.Context, userID uuid.UUID, savedItemID uuid.UUID) ([]dtos.SavedItemDTO, error)
+	ApplyCoupon(ctx context.Context, userID uuid.UUID, code string) (*dtos.CartDTO, error)
+	RemoveCoupon(ctx context.Context, userID uuid.UUID) (*dtos.CartDTO, error)
+	SetDisplayCurrency(ctx context.Context, userID uuid.UUID, currency string) (*dtos.CartDTO, error)
 	GetCartByUserId(ctx context.Context, userID uuid.UUID) (*dtos.CartDTO, error)
 	GetCartById(ctx context.Context, id uuid.UUID) (*dtos.CartDTO, error)
+	ValidateCart(ctx context.Context, id uuid.UUID) (*dtos.CartValidationDTO, error)
+	ValidateMyCart(ctx context.Context, userID uuid.UUID) (*dtos.CartValidationDTO, error)
 	DeleteCart(ctx context.Context, userID uuid.UUID) error
 }
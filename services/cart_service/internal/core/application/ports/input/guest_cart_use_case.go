@@ -0,0 +1,17 @@
+package input
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+// GuestCartUseCase manages the carts of visitors that are not logged in. Guest carts are identified by a signed token.
+type GuestCartUseCase interface {
+	CreateCart(ctx context.Context) (*dtos.GuestCartDTO, error)
+	GetCart(ctx context.Context, token string) (*dtos.CartDTO, error)
+	AddItems(ctx context.Context, token string, insertDTO []dtos.CartItemInserDTO) (*dtos.CartDTO, error)
+	RemoveItems(ctx context.Context, token string, itemIDs []uuid.UUID) (*dtos.CartDTO, error)
+	MergeCarts(ctx context.Context, token string, userID uuid.UUID) (*dtos.CartDTO, error)
+}
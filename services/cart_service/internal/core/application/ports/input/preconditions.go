@@ -0,0 +1,16 @@
+package input
+
+import "context"
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion marks ctx with the cart version the client based its change on (the If-Match header).
+// Cart use cases that modify a cart fail with domain.ErrConcurrentModification when the cart moved on.
+func WithExpectedVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+func ExpectedVersion(ctx context.Context) (int, bool) {
+	version, ok := ctx.Value(expectedVersionKey{}).(int)
+	return version, ok
+}
@@ -0,0 +1,16 @@
+package input
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type PromotionUseCase interface {
+	CreatePromotion(ctx context.Context, insertDTO dtos.PromotionInsertDTO) (*dtos.PromotionDTO, error)
+	UpdatePromotion(ctx context.Context, id uuid.UUID, insertDTO dtos.PromotionInsertDTO) (*dtos.PromotionDTO, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+	GetPromotionById(ctx context.Context, id uuid.UUID) (*dtos.PromotionDTO, error)
+	GetAllPromotions(ctx context.Context) ([]dtos.PromotionDTO, error)
+}
@@ -0,0 +1,13 @@
+package input
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+type UserEventUseCase interface {
+	// HandleUserEvent applies a user lifecycle event to the user's cart. Redeliveries of an event are ignored.
+	// It returns domain.ErrInvalidUserEvent for events that can never be applied.
+	HandleUserEvent(ctx context.Context, event dtos.UserEventDTO) error
+}
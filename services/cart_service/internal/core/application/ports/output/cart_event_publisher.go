@@ -0,0 +1,11 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+type CartEventPublisher interface {
+	PublishCartAbandoned(ctx context.Context, event dtos.CartAbandonedEvent) error
+}
@@ -0,0 +1,18 @@
+package output
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// CartEventRepository is append only, events are only changed to forget a deleted user.
+type CartEventRepository interface {
+	Append(ctx context.Context, events []domain.CartEvent) error
+	// GetByCartId returns the events of the cart that occurred up to until, oldest first. A zero until returns them all.
+	GetByCartId(ctx context.Context, cartID uuid.UUID, until time.Time) ([]domain.CartEvent, error)
+	// AnonymizeUser removes userID from its events, the cart history stays for reporting.
+	AnonymizeUser(ctx context.Context, userID uuid.UUID) error
+}
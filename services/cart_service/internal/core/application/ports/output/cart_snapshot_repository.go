@@ -0,0 +1,17 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// CartSnapshotRepository has no update, snapshots are immutable.
+type CartSnapshotRepository interface {
+	CreateSnapshot(ctx context.Context, snapshot domain.CartSnapshot) error
+	// GetByTokenHash returns domain.ErrSnapshotNotFound when no snapshot has this hash.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.CartSnapshot, error)
+	// DeleteByOwner deletes every snapshot shared by ownerID, their links stop working.
+	DeleteByOwner(ctx context.Context, ownerID uuid.UUID) error
+}
@@ -0,0 +1,11 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+)
+
+type CheckoutRepository interface {
+	CreateCheckout(ctx context.Context, checkout domain.Checkout) error
+}
@@ -0,0 +1,12 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+)
+
+type ExchangeRateProvider interface {
+	// GetRate returns domain.ErrUnsupportedCurrency when base or quote is unknown to the provider.
+	GetRate(ctx context.Context, base, quote string) (domain.ExchangeRate, error)
+}
@@ -0,0 +1,18 @@
+package output
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// GuestCartRepository stores the carts of visitors that are not logged in.
+// Guest carts expire on their own after the ttl given on creation.
+type GuestCartRepository interface {
+	CreateCart(ctx context.Context, cart domain.Cart, ttl time.Duration) error
+	UpdateCart(ctx context.Context, cart domain.Cart) error
+	GetById(ctx context.Context, id uuid.UUID) (*domain.Cart, error)
+	DeleteCart(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package output
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// InventoryReservation holds stock while a checkout is in flight.
+// Reserve is all or nothing and fails with domain.ErrInsufficientStock. A hold that is neither confirmed
+// nor released before ttl is given back by ReleaseExpired. Products without a stock level are not tracked.
+type InventoryReservation interface {
+	Reserve(ctx context.Context, reservationID uuid.UUID, items []domain.StockItem, ttl time.Duration) error
+	// Confirm turns the hold into a sale, the units are not given back.
+	Confirm(ctx context.Context, reservationID uuid.UUID) error
+	// Release gives the held units back. Releasing an unknown reservation is a no-op.
+	Release(ctx context.Context, reservationID uuid.UUID) error
+	// ReleaseExpired gives back the holds whose ttl passed and returns how many were released.
+	ReleaseExpired(ctx context.Context, now time.Time) (int, error)
+	// SeedStock starts tracking a product at quantity units. A product already tracked keeps its level,
+	// the units held and sold since it was seeded are only counted here.
+	SeedStock(ctx context.Context, productID uuid.UUID, quantity int) error
+}
@@ -0,0 +1,11 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+type OrderPublisher interface {
+	PublishOrder(ctx context.Context, event dtos.CheckoutEvent) error
+}
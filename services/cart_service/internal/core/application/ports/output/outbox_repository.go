@@ -0,0 +1,18 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// OutboxRepository stores the outbox. MarkFailed records a failed attempt, the event stays pending.
+// MarkDead records the last failure and takes the event out of GetPending for good.
+type OutboxRepository interface {
+	Save(ctx context.Context, event domain.OutboxEvent) error
+	GetPending(ctx context.Context, limit int) ([]domain.OutboxEvent, error)
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, cause error) error
+	MarkDead(ctx context.Context, id uuid.UUID, cause error) error
+}
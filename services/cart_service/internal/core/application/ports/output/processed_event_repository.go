@@ -0,0 +1,16 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// ProcessedEventRepository remembers the consumed user events so a redelivered event is applied once.
+type ProcessedEventRepository interface {
+	// MarkProcessed records event. It returns false when the event was already recorded.
+	MarkProcessed(ctx context.Context, event domain.UserEvent) (bool, error)
+	// HasProcessed tells whether an event of eventType was recorded for userID.
+	HasProcessed(ctx context.Context, userID uuid.UUID, eventType domain.UserEventType) (bool, error)
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"context"
+	"time"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
 	"github.com/google/uuid"
@@ -13,4 +14,8 @@ type CartRepository interface {
 	DeleteCart(ctx context.Context, id uuid.UUID) error
 	GetById(ctx context.Context, id uuid.UUID) (*domain.Cart, error)
 	GetByUserID(ctx context.Context, id uuid.UUID) (*domain.Cart, error)
+	// GetAbandoned returns non-empty carts not updated since idleSince that were not notified in their current idle period.
+	GetAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]domain.Cart, error)
+	// MarkAbandonedNotified records the notification. It returns false when another worker already did it.
+	MarkAbandonedNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
 }
@@ -0,0 +1,19 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+type PromotionRepository interface {
+	CreatePromotion(ctx context.Context, promotion domain.Promotion) (*domain.Promotion, error)
+	UpdatePromotion(ctx context.Context, promotion domain.Promotion) (*domain.Promotion, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+	GetById(ctx context.Context, id uuid.UUID) (*domain.Promotion, error)
+	GetByCode(ctx context.Context, code string) (*domain.Promotion, error)
+	GetAll(ctx context.Context) ([]domain.Promotion, error)
+	CountRedemptions(ctx context.Context, promotionID, userID uuid.UUID) (int, error)
+	CreateRedemption(ctx context.Context, redemption domain.PromotionRedemption) error
+}
@@ -0,0 +1,14 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+)
+
+// TaxCalculator returns the taxes charged on taxable for a buyer at address.
+// An address without tax rules yields no lines.
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, address domain.TaxAddress, taxable money.Money) ([]domain.TaxLine, error)
+}
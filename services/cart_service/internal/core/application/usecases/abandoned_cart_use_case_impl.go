@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+type AbandonedCartUseCaseImpl struct {
+	repository         output.CartRepository
+	outboxRepository   output.OutboxRepository
+	transactionManager output.TransactionManager
+	cartMappers        mappers.CartMapper
+	idleFor            time.Duration
+	batchSize          int
+}
+
+func NewAbandonedCartUseCase(
+	repository output.CartRepository,
+	outboxRepository output.OutboxRepository,
+	transactionManager output.TransactionManager,
+	idleFor time.Duration,
+	batchSize int) input.AbandonedCartUseCase {
+	return &AbandonedCartUseCaseImpl{
+		repository:         repository,
+		outboxRepository:   outboxRepository,
+		transactionManager: transactionManager,
+		idleFor:            idleFor,
+		batchSize:          batchSize,
+	}
+}
+
+func (us *AbandonedCartUseCaseImpl) GetAbandonedCarts(ctx context.Context) ([]dtos.CartAbandonedEvent, error) {
+	now := time.Now()
+	carts, err := us.repository.GetAbandoned(ctx, now.Add(-us.idleFor), us.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]dtos.CartAbandonedEvent, 0, len(carts))
+	for _, cart := range carts {
+		event, err := us.cartMappers.DomainToAbandonedEvent(cart, now)
+		if err != nil {
+			log.Printf("Abandoned carts: pricing cart %s: %v", cart.ID, err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// NotifyAbandonedCarts marks each cart and stores its event in the outbox in one transaction.
+// The mark is conditional, so concurrent workers emit a single event per idle period.
+func (us *AbandonedCartUseCaseImpl) NotifyAbandonedCarts(ctx context.Context) (int, error) {
+	now := time.Now()
+	carts, err := us.repository.GetAbandoned(ctx, now.Add(-us.idleFor), us.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	notified := 0
+	for _, cart := range carts {
+		if !cart.IsAbandonedAt(now, us.idleFor) {
+			continue
+		}
+
+		// A cart that cannot be priced is skipped so it does not hold back the rest of the batch
+		event, err := us.cartMappers.DomainToAbandonedEvent(cart, now)
+		if err != nil {
+			log.Printf("Abandoned carts: pricing cart %s: %v", cart.ID, err)
+			continue
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return notified, err
+		}
+
+		marked := false
+		err = us.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+			marked, err = us.repository.MarkAbandonedNotified(ctx, cart.ID, now)
+			if err != nil || !marked {
+				return err
+			}
+
+			return us.outboxRepository.Save(ctx, domain.NewOutboxEvent(cart.ID, domain.CartAbandonedEvent, payload))
+		})
+		if err != nil {
+			return notified, err
+		}
+
+		if marked {
+			notified++
+		}
+	}
+
+	return notified, nil
+}
+
+// AbandonedCartWorker runs NotifyAbandonedCarts on a schedule.
+type AbandonedCartWorker struct {
+	useCase  input.AbandonedCartUseCase
+	interval time.Duration
+}
+
+func NewAbandonedCartWorker(useCase input.AbandonedCartUseCase, interval time.Duration) *AbandonedCartWorker {
+	return &AbandonedCartWorker{
+		useCase:  useCase,
+		interval: interval,
+	}
+}
+
+// Start checks for abandoned carts until ctx is cancelled. Call it in its own goroutine.
+func (w *AbandonedCartWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		notified, err := w.useCase.NotifyAbandonedCarts(ctx)
+		if err != nil {
+			log.Printf("Abandoned cart worker: %v", err)
+		} else if notified > 0 {
+			log.Printf("Abandoned cart worker: notified %d carts", notified)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/inventory"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/usecases"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+const testHoldTTL = time.Minute
+
+var errDatabaseDown = errors.New("database down")
+
+// fakeCarts serves a single cart.
+type fakeCarts struct {
+	cart *domain.Cart
+}
+
+func (r *fakeCarts) CreateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
+	r.cart = &cart
+	return &cart, nil
+}
+
+func (r *fakeCarts) UpdateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
+	r.cart = &cart
+	return &cart, nil
+}
+
+func (r *fakeCarts) DeleteCart(ctx context.Context, id uuid.UUID) error {
+	r.cart = nil
+	return nil
+}
+
+func (r *fakeCarts) GetById(ctx context.Context, id uuid.UUID) (*domain.Cart, error) {
+	return r.cart, nil
+}
+
+func (r *fakeCarts) GetByUserID(ctx context.Context, id uuid.UUID) (*domain.Cart, error) {
+	return r.cart, nil
+}
+
+func (r *fakeCarts) GetAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]domain.Cart, error) {
+	return nil, nil
+}
+
+func (r *fakeCarts) MarkAbandonedNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	return false, nil
+}
+
+type fakeCheckouts struct {
+	checkouts []domain.Checkout
+	err       error
+}
+
+func (r *fakeCheckouts) CreateCheckout(ctx context.Context, checkout domain.Checkout) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.checkouts = append(r.checkouts, checkout)
+	return nil
+}
+
+// fakeTransactions runs inTransaction, if set, before the work of the transaction.
+// A failing transaction rolls back the checkouts it stored.
+type fakeTransactions struct {
+	checkouts     *fakeCheckouts
+	inTransaction func()
+}
+
+func (m *fakeTransactions) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	stored := len(m.checkouts.checkouts)
+	if m.inTransaction != nil {
+		m.inTransaction()
+	}
+
+	if err := fn(ctx); err != nil {
+		m.checkouts.checkouts = m.checkouts.checkouts[:stored]
+		return err
+	}
+	return nil
+}
+
+type fakeCatalog struct {
+	products map[uuid.UUID]facadeService.Product
+}
+
+func (c *fakeCatalog) GetProductById(ctx context.Context, id uuid.UUID) (*facadeService.Product, error) {
+	product, found := c.products[id]
+	if !found {
+		return nil, &facadeService.ProductNotFoundError{ProductID: id}
+	}
+	return &product, nil
+}
+
+func (c *fakeCatalog) GetProductsByIdIn(ctx context.Context, ids []uuid.UUID) (*[]facadeService.Product, error) {
+	products := []facadeService.Product{}
+	for _, id := range ids {
+		if product, found := c.products[id]; found {
+			products = append(products, product)
+		}
+	}
+	return &products, nil
+}
+
+func stock(units int) *int {
+	return &units
+}
+
+func TestBuyProductHoldsStock(t *testing.T) {
+	tests := []struct {
+		name          string
+		catalogStock  *int
+		trackedStock  *int
+		quantity      int
+		checkoutErr   error
+		reapMidCommit bool
+		wantErr       error
+		wantStock     *int
+	}{
+		{name: "seeds the stock from the catalog", catalogStock: stock(5), quantity: 2, wantStock: stock(3)},
+		{name: "keeps the tracked level over the catalog one", catalogStock: stock(5), trackedStock: stock(1), quantity: 1, wantStock: stock(0)},
+		{name: "not enough stock", catalogStock: stock(1), quantity: 2, wantErr: domain.ErrInsufficientStock, wantStock: stock(1)},
+		{name: "catalog does not count the product", quantity: 2},
+		{name: "transaction fails", catalogStock: stock(5), quantity: 2, checkoutErr: errDatabaseDown, wantErr: errDatabaseDown, wantStock: stock(5)},
+		{name: "hold reaped before the commit", catalogStock: stock(5), quantity: 2, reapMidCommit: true, wantErr: domain.ErrReservationNotFound, wantStock: stock(5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			product := facadeService.Product{
+				Id:          uuid.New(),
+				Name:        "book",
+				Price:       money.New(1999, "USD"),
+				IsAvalaible: true,
+				Disccount:   money.Zero("USD"),
+				Stock:       tt.catalogStock,
+			}
+
+			reservations := inventory.NewInMemoryInventoryReservation()
+			if tt.trackedStock != nil {
+				reservations.SeedStock(ctx, product.Id, *tt.trackedStock)
+			}
+
+			userID := uuid.New()
+			checkouts := &fakeCheckouts{err: tt.checkoutErr}
+			transactions := &fakeTransactions{checkouts: checkouts}
+			if tt.reapMidCommit {
+				transactions.inTransaction = func() {
+					reservations.ReleaseExpired(ctx, time.Now().Add(2*testHoldTTL))
+				}
+			}
+
+			useCase := usecases.NewCartUseCase(
+				&fakeCarts{cart: domain.NewCart(userID)},
+				checkouts,
+				newFakeOutbox(),
+				nil,
+				transactions,
+				&fakeCatalog{products: map[uuid.UUID]facadeService.Product{product.Id: product}},
+				nil,
+				nil,
+				nil,
+				reservations,
+				testHoldTTL,
+				nil,
+			)
+
+			_, err := useCase.BuyProduct(ctx, userID, dtos.CartItemInserDTO{ProductID: product.Id, Quantity: tt.quantity})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			units, tracked := reservations.Stock(product.Id)
+			if tt.wantStock == nil && tracked {
+				t.Errorf("product is tracked at %d units, want it untracked", units)
+			}
+			if tt.wantStock != nil && (!tracked || units != *tt.wantStock) {
+				t.Errorf("got %d units (tracked %v), want %d", units, tracked, *tt.wantStock)
+			}
+
+			// A committed checkout has no hold left for the reaper to give back
+			if released, _ := reservations.ReleaseExpired(ctx, time.Now().Add(2*testHoldTTL)); released != 0 {
+				t.Errorf("reaper released %d holds after the checkout", released)
+			}
+			if after, _ := reservations.Stock(product.Id); after != units {
+				t.Errorf("stock moved from %d to %d after reaping", units, after)
+			}
+
+			wantCheckouts := 0
+			if tt.wantErr == nil {
+				wantCheckouts = 1
+			}
+			if len(checkouts.checkouts) != wantCheckouts {
+				t.Errorf("got %d stored checkouts, want %d", len(checkouts.checkouts), wantCheckouts)
+			}
+		})
+	}
+}
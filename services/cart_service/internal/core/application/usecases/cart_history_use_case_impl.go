@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type CartHistoryUseCaseImpl struct {
+	eventRepository output.CartEventRepository
+	eventMappers    mappers.CartEventMapper
+	cartMappers     mappers.CartMapper
+}
+
+func NewCartHistoryUseCase(eventRepository output.CartEventRepository) input.CartHistoryUseCase {
+	return &CartHistoryUseCaseImpl{eventRepository: eventRepository}
+}
+
+func (us *CartHistoryUseCaseImpl) GetTimeline(ctx context.Context, cartID uuid.UUID) (*dtos.CartTimelineDTO, error) {
+	events, err := us.eventRepository.GetByCartId(ctx, cartID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, domain.ErrCartHistoryNotFound
+	}
+
+	return &dtos.CartTimelineDTO{
+		CartID: cartID,
+		UserID: events[0].UserID,
+		Events: us.eventMappers.DomainsToDTOs(events),
+	}, nil
+}
+
+func (us *CartHistoryUseCaseImpl) GetCartAsOf(ctx context.Context, cartID uuid.UUID, at time.Time) (*dtos.CartDTO, error) {
+	events, err := us.eventRepository.GetByCartId(ctx, cartID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, domain.ErrCartHistoryNotFound
+	}
+
+	cart := domain.RebuildCart(cartID, events[0].UserID, events)
+	return us.cartMappers.DomainToDTO(*cart)
+}
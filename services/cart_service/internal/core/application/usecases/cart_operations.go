@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/google/uuid"
+)
+
+var errInvalidOperation = errors.New("cart: invalid operation")
+
+// unknownOperationErrorMessage is the message of the operations failing with CartOperationErrUnknown.
+const unknownOperationErrorMessage = "An unexpected error occurred"
+
+// applyOperation applies one operation of a bulk request to cart.
+// products holds the catalog data fetched for the request, see operationProductIDs.
+func (us *CartUseCaseImpl) applyOperation(ctx context.Context, cart *domain.Cart, operation dtos.CartOperationDTO, products map[uuid.UUID]facadeService.Product) error {
+	switch operation.Op {
+	case dtos.CartOperationAdd:
+		if operation.ProductID == uuid.Nil {
+			return fmt.Errorf("%w, add requires a product_id", errInvalidOperation)
+		}
+
+		product, found := products[operation.ProductID]
+		if !found {
+			return &facadeService.ProductNotFoundError{ProductID: operation.ProductID}
+		}
+		if !product.IsAvalaible {
+			return domain.ErrProductUnavailable.Withf("cart: product %s is not available", operation.ProductID)
+		}
+
+		item := us.itemMappers.ProductToItem(product, operation.Quantity, cart.ID)
+		if err := validateCourses(ctx, us.courseService, cart.UserID, []domain.CartItem{item}); err != nil {
+			return err
+		}
+		return cart.AddItem(item)
+
+	case dtos.CartOperationRemove:
+		if _, err := cart.GetItem(operation.ItemID); err != nil {
+			return err
+		}
+		return cart.RemoveItem(operation.ItemID)
+
+	case dtos.CartOperationSetQuantity:
+		item, err := cart.GetItem(operation.ItemID)
+		if err != nil {
+			return err
+		}
+
+		product, found := products[item.ProductID]
+		if !found {
+			return &facadeService.ProductNotFoundError{ProductID: item.ProductID}
+		}
+
+		return cart.UpdateItemQuantity(operation.ItemID, operation.Quantity, product.MaxQuantity)
+
+	default:
+		return fmt.Errorf("%w %q, expected %s, %s or %s", errInvalidOperation, operation.Op,
+			dtos.CartOperationAdd, dtos.CartOperationRemove, dtos.CartOperationSetQuantity)
+	}
+}
+
+// operationProductIDs lists, once each, the products to add and the products of the lines whose quantity is set.
+func operationProductIDs(cart domain.Cart, operations []dtos.CartOperationDTO) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	productIDs := []uuid.UUID{}
+
+	for _, operation := range operations {
+		productID := uuid.Nil
+		switch operation.Op {
+		case dtos.CartOperationAdd:
+			productID = operation.ProductID
+		case dtos.CartOperationSetQuantity:
+			if item, err := cart.GetItem(operation.ItemID); err == nil {
+				productID = item.ProductID
+			}
+		}
+
+		if productID != uuid.Nil && !seen[productID] {
+			seen[productID] = true
+			productIDs = append(productIDs, productID)
+		}
+	}
+
+	return productIDs
+}
+
+// cartOperationErrorCode maps the error of a failed operation to the code returned to the client.
+// Errors of the cart rules keep their domain code.
+func cartOperationErrorCode(err error) string {
+	var domainErr *domain.DomainError
+	switch {
+	case errors.Is(err, errInvalidOperation):
+		return dtos.CartOperationErrInvalidOperation
+	case errors.Is(err, facadeService.ErrProductNotFound), errors.Is(err, facadeService.ErrCourseNotFound):
+		return dtos.CartOperationErrProductNotFound
+	case errors.As(err, &domainErr):
+		return domainErr.Code
+	default:
+		return dtos.CartOperationErrUnknown
+	}
+}
+
+// cartOperationErrorMessage is the message returned with code. The text of an unknown error may carry
+// internal details, it is logged and the client gets a generic message.
+func cartOperationErrorMessage(err error, code string) string {
+	if code == dtos.CartOperationErrUnknown {
+		log.Printf("Cart operations: %v", err)
+		return unknownOperationErrorMessage
+	}
+	return err.Error()
+}
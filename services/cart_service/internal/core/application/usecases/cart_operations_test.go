@@ -0,0 +1,118 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/inventory"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/usecases"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+// fakeCourses fails every call with err.
+type fakeCourses struct {
+	err error
+}
+
+func (c *fakeCourses) GetCourseById(ctx context.Context, id uuid.UUID) (*facadeService.Course, error) {
+	return nil, c.err
+}
+
+func (c *fakeCourses) IsEnrolled(ctx context.Context, courseID uuid.UUID, userID uuid.UUID) (bool, error) {
+	return false, c.err
+}
+
+// fakeUsers has no address, the carts are returned untaxed.
+type fakeUsers struct{}
+
+func (u fakeUsers) GetDefaultAddress(ctx context.Context, userID uuid.UUID) (*facadeService.Address, error) {
+	return nil, facadeService.ErrAddressNotFound
+}
+
+// countingCarts counts the saved carts.
+type countingCarts struct {
+	*fakeCarts
+	updates int
+}
+
+func (r *countingCarts) UpdateCart(ctx context.Context, cart domain.Cart) (*domain.Cart, error) {
+	r.updates++
+	return r.fakeCarts.UpdateCart(ctx, cart)
+}
+
+func TestApplyOperationsCourseServiceErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		courseErr   error
+		wantErr     error
+		wantUpdates int
+	}{
+		{
+			name:      "course service unavailable",
+			courseErr: fmt.Errorf("%w: course service: request failed: dial tcp 10.0.0.7:8080: connection refused", facadeService.ErrServiceUnavailable),
+			wantErr:   facadeService.ErrServiceUnavailable,
+		},
+		{
+			name:        "unexpected course service error",
+			courseErr:   errors.New("course service: decoding response from 10.0.0.7:8080: unexpected EOF"),
+			wantUpdates: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pen := facadeService.Product{Id: uuid.New(), Name: "pen", Price: money.New(250, "USD"), IsAvalaible: true, Disccount: money.Zero("USD")}
+			course := facadeService.Product{Id: uuid.New(), Name: "go course", Type: "COURSE", Price: money.New(4999, "USD"), IsAvalaible: true, Disccount: money.Zero("USD")}
+
+			userID := uuid.New()
+			carts := &countingCarts{fakeCarts: &fakeCarts{cart: domain.NewCart(userID)}}
+			useCase := usecases.NewCartUseCase(
+				carts,
+				&fakeCheckouts{},
+				newFakeOutbox(),
+				nil,
+				nil,
+				&fakeCatalog{products: map[uuid.UUID]facadeService.Product{pen.Id: pen, course.Id: course}},
+				fakeUsers{},
+				&fakeCourses{err: tt.courseErr},
+				nil,
+				inventory.NewInMemoryInventoryReservation(),
+				testHoldTTL,
+				nil,
+			)
+
+			result, err := useCase.ApplyOperations(context.Background(), userID, dtos.CartOperationsDTO{
+				Operations: []dtos.CartOperationDTO{
+					{Op: dtos.CartOperationAdd, ProductID: pen.Id, Quantity: 1},
+					{Op: dtos.CartOperationAdd, ProductID: course.Id, Quantity: 1},
+				},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if carts.updates != tt.wantUpdates {
+				t.Errorf("got %d saved carts, want %d", carts.updates, tt.wantUpdates)
+			}
+			if err != nil {
+				return
+			}
+
+			if result.Applied != 1 || result.Failed != 1 {
+				t.Fatalf("got %d applied and %d failed, want 1 of each", result.Applied, result.Failed)
+			}
+
+			// The error text stays in the logs
+			failed := result.Results[1]
+			if failed.Code != dtos.CartOperationErrUnknown || strings.Contains(failed.Message, "10.0.0.7") {
+				t.Errorf("got %s %q, want %s with a generic message", failed.Code, failed.Message, dtos.CartOperationErrUnknown)
+			}
+		})
+	}
+}
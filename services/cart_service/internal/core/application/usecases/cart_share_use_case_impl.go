@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type CartShareUseCaseImpl struct {
+	snapshotRepository output.CartSnapshotRepository
+	cartRepository     output.CartRepository
+	cartUseCase        input.CartUseCase
+	snapshotMappers    mappers.CartSnapshotMapper
+	ttl                time.Duration
+}
+
+func NewCartShareUseCase(
+	snapshotRepository output.CartSnapshotRepository,
+	cartRepository output.CartRepository,
+	cartUseCase input.CartUseCase,
+	ttl time.Duration) input.CartShareUseCase {
+	return &CartShareUseCaseImpl{
+		snapshotRepository: snapshotRepository,
+		cartRepository:     cartRepository,
+		cartUseCase:        cartUseCase,
+		ttl:                ttl,
+	}
+}
+
+func (us *CartShareUseCaseImpl) ShareCart(ctx context.Context, userID uuid.UUID) (*dtos.CartShareDTO, error) {
+	cart, err := us.cartRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, token, err := domain.NewCartSnapshot(*cart, us.ttl, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.snapshotRepository.CreateSnapshot(ctx, *snapshot); err != nil {
+		return nil, err
+	}
+
+	return &dtos.CartShareDTO{Token: token, ExpiresAt: snapshot.ExpiresAt}, nil
+}
+
+func (us *CartShareUseCaseImpl) GetSharedCart(ctx context.Context, token string) (*dtos.CartSnapshotDTO, error) {
+	snapshot, err := us.getSnapshot(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.snapshotMappers.DomainToDTO(*snapshot)
+}
+
+// ImportSharedCart goes through AddItems so the lines are priced and validated like any other addition.
+// A user without a cart gets one.
+func (us *CartShareUseCaseImpl) ImportSharedCart(ctx context.Context, token string, userID uuid.UUID) (*dtos.CartDTO, error) {
+	snapshot, err := us.getSnapshot(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if cart, _ := us.cartRepository.GetByUserID(ctx, userID); cart == nil {
+		if err := us.cartUseCase.CreateCart(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return us.cartUseCase.AddItems(ctx, userID, us.snapshotMappers.DomainToInsertDTOs(*snapshot))
+}
+
+func (us *CartShareUseCaseImpl) getSnapshot(ctx context.Context, token string) (*domain.CartSnapshot, error) {
+	if token == "" {
+		return nil, domain.ErrSnapshotNotFound
+	}
+
+	snapshot, err := us.snapshotRepository.GetByTokenHash(ctx, domain.HashShareToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := snapshot.CheckNotExpired(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
@@ -2,8 +2,11 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
@@ -15,25 +18,56 @@ import (
 )
 
 type CartUseCaseImpl struct {
-	repository     output.CartRepository
-	itemMappers    mappers.CartItemMapper
-	cartMappers    mappers.CartMapper
-	productService facadeService.ProductFacadeService
+	repository          output.CartRepository
+	checkoutRepository  output.CheckoutRepository
+	outboxRepository    output.OutboxRepository
+	promotionRepository output.PromotionRepository
+	transactionManager  output.TransactionManager
+	itemMappers         mappers.CartItemMapper
+	cartMappers         mappers.CartMapper
+	checkoutMappers     mappers.CheckoutMapper
+	productService      facadeService.ProductFacadeService
+	userService         facadeService.UserFacadeService
+	courseService       facadeService.CourseFacadeService
+	taxCalculator       output.TaxCalculator
+	inventory           output.InventoryReservation
+	holdTTL             time.Duration
+	exchangeRates       output.ExchangeRateProvider
 }
 
 func NewCartUseCase(
 	repository output.CartRepository,
-	productService facadeService.ProductFacadeService) input.CartUseCase {
+	checkoutRepository output.CheckoutRepository,
+	outboxRepository output.OutboxRepository,
+	promotionRepository output.PromotionRepository,
+	transactionManager output.TransactionManager,
+	productService facadeService.ProductFacadeService,
+	userService facadeService.UserFacadeService,
+	courseService facadeService.CourseFacadeService,
+	taxCalculator output.TaxCalculator,
+	inventory output.InventoryReservation,
+	holdTTL time.Duration,
+	exchangeRates output.ExchangeRateProvider) input.CartUseCase {
 	return &CartUseCaseImpl{
-		repository:     repository,
-		productService: productService,
+		repository:          repository,
+		checkoutRepository:  checkoutRepository,
+		outboxRepository:    outboxRepository,
+		promotionRepository: promotionRepository,
+		transactionManager:  transactionManager,
+		productService:      productService,
+		userService:         userService,
+		courseService:       courseService,
+		taxCalculator:       taxCalculator,
+		inventory:           inventory,
+		holdTTL:             holdTTL,
+		exchangeRates:       exchangeRates,
 	}
 }
 
 func (us *CartUseCaseImpl) CreateCart(ctx context.Context, userID uuid.UUID) error {
 	cart, _ := us.repository.GetByUserID(ctx, userID)
 	if cart != nil {
-		return errors.New("user already have a cart")
+		return domain.ErrCartAlreadyExists
 	}
 
 	newCart := domain.NewCart(userID)
@@ -44,21 +78,81 @@ func (us *CartUseCaseImpl) CreateCart(ctx context.Context, userID uuid.UUID) err
 	return nil
 }
 
-func (us *CartUseCaseImpl) Buy(ctx context.Context, userID uuid.UUID, excludeItemsIDs []*uuid.UUID) error {
+func (us *CartUseCaseImpl) Buy(ctx context.Context, userID uuid.UUID, excludeItemsIDs []*uuid.UUID) (*dtos.PurchaseDetails, error) {
 	cart, err := us.repository.GetByUserID(ctx, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	subTotal, err := cart.Buy(excludeItemsIDs)
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	// New prices are saved before failing, so buying again is how the client acknowledges them
+	changes, err := us.refreshPrices(ctx, cart, excludeItemsIDs)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if len(changes) > 0 {
+		if _, err := us.repository.UpdateCart(ctx, *cart); err != nil {
+			return nil, err
+		}
+		return nil, &domain.CartChangedError{Changes: changes}
 	}
 
-	// Conect to Payment Service
-	fmt.Printf("Sending a request to Payment Service: UserId %s, SubTotal %f\n", userID, subTotal)
+	if cart.Promotion != nil {
+		if err := us.validateCouponRedemption(ctx, *cart.Promotion, userID); err != nil {
+			return nil, err
+		}
+	}
 
-	return nil
+	checkout, err := cart.Buy(excludeItemsIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// A course may have been bought elsewhere since it was added
+	if err := validateCourses(ctx, us.courseService, userID, checkout.Items); err != nil {
+		return nil, err
+	}
+
+	if err := us.lockExchangeRate(ctx, cart.DisplayCurrency, checkout); err != nil {
+		return nil, err
+	}
+
+	return us.commitCheckout(ctx, checkout, cart)
+}
+
+func (us *CartUseCaseImpl) BuyProduct(ctx context.Context, userID uuid.UUID, insertDTO dtos.CartItemInserDTO) (*dtos.PurchaseDetails, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := us.productService.GetProductById(ctx, insertDTO.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !product.IsAvalaible {
+		return nil, domain.ErrProductUnavailable.Withf("cart: product %s is not available", product.Id)
+	}
+
+	item := us.itemMappers.ProductToItem(*product, insertDTO.Quantity, cart.ID)
+	checkout, err := cart.BuyProduct(item)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCourses(ctx, us.courseService, userID, checkout.Items); err != nil {
+		return nil, err
+	}
+
+	if err := us.lockExchangeRate(ctx, cart.DisplayCurrency, checkout); err != nil {
+		return nil, err
+	}
+
+	return us.commitCheckout(ctx, checkout, nil)
 }
 
 func (us *CartUseCaseImpl) AddItems(ctx context.Context, userID uuid.UUID, insertDTOS []dtos.CartItemInserDTO) (*dtos.CartDTO, error) {
@@ -67,28 +161,75 @@ func (us *CartUseCaseImpl) AddItems(ctx context.Context, userID uuid.UUID, inser
 		return nil, err
 	}
 
-	productData, err := us.fetchProductData(insertDTOS)
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	productData, err := fetchProductData(ctx, us.productService, insertDTOS)
 	if err != nil {
 		return nil, err
 	}
 
 	items := us.itemMappers.ProductToItemList(*productData, cart.ID)
+	if err := validateCourses(ctx, us.courseService, userID, items); err != nil {
+		return nil, err
+	}
 
-	cart.AddItems(items)
+	if err := cart.AddItems(items); err != nil {
+		return nil, err
+	}
 
 	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
 	if err != nil {
 		return nil, err
 	}
 
-	return us.cartMappers.DomainToDTO(*cartUpdated), nil
+	return us.toCartDTO(ctx, *cartUpdated)
 }
+
+// UpdateItemQuantity sets the quantity of one line, checking the current per-product limit of the catalog.
+func (us *CartUseCaseImpl) UpdateItemQuantity(ctx context.Context, userID uuid.UUID, itemID uuid.UUID, quantity int) (*dtos.CartDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	item, err := cart.GetItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := us.productService.GetProductById(ctx, item.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cart.UpdateItemQuantity(itemID, quantity, product.MaxQuantity); err != nil {
+		return nil, err
+	}
+
+	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.toCartDTO(ctx, *cartUpdated)
+}
+
 func (us *CartUseCaseImpl) RemoveItems(ctx context.Context, userID uuid.UUID, itemIDs []uuid.UUID) (*dtos.CartDTO, error) {
 	cart, err := us.repository.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
 	err = cart.RemoveItems(itemIDs)
 	if err != nil {
 		return nil, err
@@ -99,7 +240,285 @@ func (us *CartUseCaseImpl) RemoveItems(ctx context.Context, userID uuid.UUID, it
 		return nil, err
 	}
 
-	return us.cartMappers.DomainToDTO(*cartUpdated), nil
+	return us.toCartDTO(ctx, *cartUpdated)
+}
+
+// ApplyOperations applies the add, remove and set_quantity operations in request order and saves the cart once.
+// The catalog is read a single time for every product involved. A failing operation leaves the cart as the
+// previous ones left it, with AllOrNothing any failure discards all the changes. When a service the operations
+// depend on is unavailable nothing is saved and the error is returned.
+func (us *CartUseCaseImpl) ApplyOperations(ctx context.Context, userID uuid.UUID, request dtos.CartOperationsDTO) (*dtos.CartOperationsResultDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	products, err := fetchProducts(ctx, us.productService, operationProductIDs(*cart, request.Operations))
+	if err != nil {
+		return nil, err
+	}
+
+	// AddItem changes quantities in place, keep the loaded lines for an aborted request
+	original := *cart
+	original.Items = append([]domain.CartItem{}, cart.Items...)
+
+	result := &dtos.CartOperationsResultDTO{Results: make([]dtos.CartOperationResultDTO, len(request.Operations))}
+	for i, operation := range request.Operations {
+		opResult := dtos.CartOperationResultDTO{Index: i, Op: operation.Op, Status: dtos.CartOperationApplied}
+		if err := us.applyOperation(ctx, cart, operation, products); err != nil {
+			// A service that cannot be reached fails the request, not the operation
+			if errors.Is(err, facadeService.ErrServiceUnavailable) {
+				return nil, err
+			}
+
+			opResult.Status = dtos.CartOperationFailed
+			opResult.Code = cartOperationErrorCode(err)
+			opResult.Message = cartOperationErrorMessage(err, opResult.Code)
+			result.Failed++
+		} else {
+			result.Applied++
+		}
+		result.Results[i] = opResult
+	}
+
+	if request.AllOrNothing && result.Failed > 0 {
+		for i := range result.Results {
+			if result.Results[i].Status == dtos.CartOperationApplied {
+				result.Results[i].Status = dtos.CartOperationSkipped
+				result.Results[i].Code = dtos.CartOperationErrAborted
+			}
+		}
+		result.Applied = 0
+		cart = &original
+	}
+
+	if result.Applied > 0 {
+		if cart, err = us.repository.UpdateCart(ctx, *cart); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Cart, err = us.toCartDTO(ctx, *cart); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSavedItems lists the saved for later items with their current catalog price.
+func (us *CartUseCaseImpl) GetSavedItems(ctx context.Context, userID uuid.UUID) ([]dtos.SavedItemDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.savedItemsToDTOs(ctx, cart.SavedItems)
+}
+
+func (us *CartUseCaseImpl) MoveToSaved(ctx context.Context, userID uuid.UUID, itemID uuid.UUID) (*dtos.CartDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := cart.MoveToSaved(itemID); err != nil {
+		return nil, err
+	}
+
+	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.toCartDTO(ctx, *cartUpdated)
+}
+
+// MoveToCart puts a saved item back in the cart at its current catalog price.
+func (us *CartUseCaseImpl) MoveToCart(ctx context.Context, userID uuid.UUID, savedItemID uuid.UUID) (*dtos.CartDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	var savedItem *domain.CartItem
+	for i, saved := range cart.SavedItems {
+		if saved.ID == savedItemID {
+			savedItem = &cart.SavedItems[i]
+		}
+	}
+	if savedItem == nil {
+		return nil, domain.ErrSavedItemNotFound
+	}
+
+	product, err := us.productService.GetProductById(ctx, savedItem.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCourses(ctx, us.courseService, userID, []domain.CartItem{*savedItem}); err != nil {
+		return nil, err
+	}
+
+	current := domain.CatalogPrice{UnitPrice: product.Price, Discount: product.Disccount, Available: product.IsAvalaible}
+	if err := cart.MoveToCart(savedItemID, current, product.MaxQuantity); err != nil {
+		return nil, err
+	}
+
+	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.toCartDTO(ctx, *cartUpdated)
+}
+
+func (us *CartUseCaseImpl) RemoveSavedItem(ctx context.Context, userID uuid.UUID, savedItemID uuid.UUID) ([]dtos.SavedItemDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := cart.RemoveSavedItem(savedItemID); err != nil {
+		return nil, err
+	}
+
+	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.savedItemsToDTOs(ctx, cartUpdated.SavedItems)
+}
+
+func (us *CartUseCaseImpl) ApplyCoupon(ctx context.Context, userID uuid.UUID, code string) (*dtos.CartDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	promotion, err := us.promotionRepository.GetByCode(ctx, code)
+	if err != nil {
+		return nil, domain.ErrCouponNotFound
+	}
+
+	timesUsed, err := us.promotionRepository.CountRedemptions(ctx, promotion.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cart.ApplyCoupon(*promotion, timesUsed, time.Now()); err != nil {
+		return nil, err
+	}
+
+	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.toCartDTO(ctx, *cartUpdated)
+}
+
+func (us *CartUseCaseImpl) RemoveCoupon(ctx context.Context, userID uuid.UUID) (*dtos.CartDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := cart.RemoveCoupon(); err != nil {
+		return nil, err
+	}
+
+	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.toCartDTO(ctx, *cartUpdated)
+}
+
+// SetDisplayCurrency shows the cart totals in currency too. An empty currency goes back to the cart currency only.
+func (us *CartUseCaseImpl) SetDisplayCurrency(ctx context.Context, userID uuid.UUID, currency string) (*dtos.CartDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := cart.SetDisplayCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	if cart.DisplayCurrency != "" {
+		if _, err := us.exchangeRates.GetRate(ctx, cart.Currency(), cart.DisplayCurrency); err != nil {
+			return nil, err
+		}
+	}
+
+	cartUpdated, err := us.repository.UpdateCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.toCartDTO(ctx, *cartUpdated)
+}
+
+// ValidateCart runs the checkout price check without buying or saving anything.
+func (us *CartUseCaseImpl) ValidateCart(ctx context.Context, id uuid.UUID) (*dtos.CartValidationDTO, error) {
+	cart, err := us.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.validate(ctx, cart)
+}
+
+// ValidateMyCart is ValidateCart on the cart of userID.
+func (us *CartUseCaseImpl) ValidateMyCart(ctx context.Context, userID uuid.UUID) (*dtos.CartValidationDTO, error) {
+	cart, err := us.repository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.validate(ctx, cart)
+}
+
+func (us *CartUseCaseImpl) validate(ctx context.Context, cart *domain.Cart) (*dtos.CartValidationDTO, error) {
+	changes, err := us.refreshPrices(ctx, cart, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.CartValidationDTO{
+		CartID:  cart.ID,
+		Valid:   len(changes) == 0,
+		Changes: us.cartMappers.ChangesToDTOs(changes),
+	}, nil
 }
 
 func (us *CartUseCaseImpl) GetCartByUserId(ctx context.Context, userID uuid.UUID) (*dtos.CartDTO, error) {
@@ -108,7 +527,7 @@ func (us *CartUseCaseImpl) GetCartByUserId(ctx context.Context, userID uuid.UUID
 		return nil, err
 	}
 
-	return us.cartMappers.DomainToDTO(*cart), nil
+	return us.toCartDTO(ctx, *cart)
 }
 
 func (us *CartUseCaseImpl) GetCartById(ctx context.Context, id uuid.UUID) (*dtos.CartDTO, error) {
@@ -117,7 +536,7 @@ func (us *CartUseCaseImpl) GetCartById(ctx context.Context, id uuid.UUID) (*dtos
 		return nil, err
 	}
 
-	return us.cartMappers.DomainToDTO(*cart), nil
+	return us.toCartDTO(ctx, *cart)
 }
 
 func (us *CartUseCaseImpl) DeleteCart(ctx context.Context, userID uuid.UUID) error {
@@ -129,26 +548,292 @@ func (us *CartUseCaseImpl) DeleteCart(ctx context.Context, userID uuid.UUID) err
 	return nil
 }
 
-func (us *CartUseCaseImpl) fetchProductData(insertDTOS []dtos.CartItemInserDTO) (*[]dtos.CartItemFetchedDTO, error) {
+// commitCheckout holds the stock of the checkout, then stores the checkout record and its order event in one transaction.
+// A checkout holding courses also stores the event enrolling the buyer in them.
+// When cart is not nil its new state is saved in that same transaction.
+// The hold is confirmed as the last step of the transaction, so a hold the ReservationReaper already gave back
+// fails the checkout instead of selling the units twice. If the transaction fails the hold is released.
+func (us *CartUseCaseImpl) commitCheckout(ctx context.Context, checkout *domain.Checkout, cart *domain.Cart) (*dtos.PurchaseDetails, error) {
+	event := us.checkoutMappers.DomainToEvent(*checkout)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var coursePayload []byte
+	coursePurchase := us.checkoutMappers.DomainToCoursePurchase(*checkout)
+	if coursePurchase != nil {
+		if coursePayload, err = json.Marshal(coursePurchase); err != nil {
+			return nil, err
+		}
+	}
+
+	stockItems := checkout.StockItems()
+	if err := us.seedStock(ctx, stockItems); err != nil {
+		return nil, err
+	}
+
+	if err := us.inventory.Reserve(ctx, checkout.ID, stockItems, us.holdTTL); err != nil {
+		return nil, err
+	}
+
+	err = us.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := us.checkoutRepository.CreateCheckout(ctx, *checkout); err != nil {
+			return err
+		}
+
+		outboxEvent := domain.NewOutboxEvent(checkout.CartID, domain.OrderCreatedEvent, payload)
+		if err := us.outboxRepository.Save(ctx, outboxEvent); err != nil {
+			return err
+		}
+
+		if coursePurchase != nil {
+			coursesEvent := domain.NewOutboxEvent(checkout.CartID, domain.CoursesPurchasedEvent, coursePayload)
+			if err := us.outboxRepository.Save(ctx, coursesEvent); err != nil {
+				return err
+			}
+		}
+
+		if checkout.PromotionID != nil {
+			redemption := domain.NewPromotionRedemption(*checkout.PromotionID, checkout.UserID, checkout.ID)
+			if err := us.promotionRepository.CreateRedemption(ctx, redemption); err != nil {
+				return err
+			}
+		}
+
+		if cart != nil {
+			if _, err := us.repository.UpdateCart(ctx, *cart); err != nil {
+				return err
+			}
+		}
+
+		// A commit failing after the confirm leaves the units sold, which undersells but never oversells
+		return us.inventory.Confirm(ctx, checkout.ID)
+	})
+	if err != nil {
+		if releaseErr := us.inventory.Release(ctx, checkout.ID); releaseErr != nil {
+			log.Printf("Inventory: releasing hold of checkout %s: %v", checkout.ID, releaseErr)
+		}
+		return nil, err
+	}
+
+	return &event.Purchase, nil
+}
+
+// seedStock starts tracking the stock the catalog counts for the products of items.
+func (us *CartUseCaseImpl) seedStock(ctx context.Context, items []domain.StockItem) error {
+	productIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+
+	products, err := fetchProducts(ctx, us.productService, productIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		if product.Stock == nil {
+			continue
+		}
+		if err := us.inventory.SeedStock(ctx, product.Id, *product.Stock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toCartDTO maps the cart, adds the taxes for the buyer's default address and the totals in the display currency.
+// Without a reachable default address the cart is returned untaxed, its grand total equals its total.
+// Without a rate the display totals are left out.
+func (us *CartUseCaseImpl) toCartDTO(ctx context.Context, cart domain.Cart) (*dtos.CartDTO, error) {
+	cartDTO, err := us.cartMappers.DomainToDTO(cart)
+	if err != nil {
+		return nil, err
+	}
+	us.applyTaxes(ctx, cart, cartDTO)
+
+	if cart.NeedsConversion() {
+		rate, err := us.exchangeRates.GetRate(ctx, cart.Currency(), cart.DisplayCurrency)
+		if err != nil {
+			log.Printf("Exchange rates: converting cart %s to %s: %v", cart.ID, cart.DisplayCurrency, err)
+			return cartDTO, nil
+		}
+		us.cartMappers.ApplyExchangeRate(cartDTO, rate)
+	}
+
+	return cartDTO, nil
+}
+
+func (us *CartUseCaseImpl) applyTaxes(ctx context.Context, cart domain.Cart, cartDTO *dtos.CartDTO) {
+	if len(cart.Items) == 0 {
+		return
+	}
+
+	address, err := us.userService.GetDefaultAddress(ctx, cart.UserID)
+	if err != nil {
+		if !errors.Is(err, facadeService.ErrAddressNotFound) {
+			log.Printf("Tax: fetching default address of user %s: %v", cart.UserID, err)
+		}
+		return
+	}
+
+	taxAddress := domain.NewTaxAddress(address.Country, address.State, address.PostalCode)
+	lines, err := us.taxCalculator.CalculateTax(ctx, taxAddress, cartDTO.Total)
+	if err != nil {
+		log.Printf("Tax: calculating taxes of cart %s: %v", cart.ID, err)
+		return
+	}
+
+	if err := us.cartMappers.ApplyTaxes(cartDTO, lines); err != nil {
+		log.Printf("Tax: applying taxes to cart %s: %v", cart.ID, err)
+	}
+}
+
+// lockExchangeRate fetches the rate to the display currency once for the checkout, the checkout keeps it.
+// Failing to get it fails the checkout so the buyer is never charged a total they were not shown.
+func (us *CartUseCaseImpl) lockExchangeRate(ctx context.Context, displayCurrency string, checkout *domain.Checkout) error {
+	base := checkout.Total.Currency()
+	if displayCurrency == "" || displayCurrency == base {
+		return nil
+	}
+
+	rate, err := us.exchangeRates.GetRate(ctx, base, displayCurrency)
+	if err != nil {
+		return fmt.Errorf("cart: cannot get the %s exchange rate: %w", displayCurrency, err)
+	}
+
+	checkout.LockExchangeRate(rate)
+	return nil
+}
+
+func (us *CartUseCaseImpl) savedItemsToDTOs(ctx context.Context, savedItems []domain.CartItem) ([]dtos.SavedItemDTO, error) {
+	savedDTOs := make([]dtos.SavedItemDTO, len(savedItems))
+	if len(savedItems) == 0 {
+		return savedDTOs, nil
+	}
+
+	productIDs := make([]uuid.UUID, len(savedItems))
+	for i, item := range savedItems {
+		productIDs[i] = item.ProductID
+	}
+
+	products, err := us.productService.GetProductsByIdIn(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	productMap := make(map[uuid.UUID]facadeService.Product, len(*products))
+	for _, product := range *products {
+		productMap[product.Id] = product
+	}
+
+	for i, item := range savedItems {
+		var current *facadeService.Product
+		if product, found := productMap[item.ProductID]; found {
+			current = &product
+		}
+		savedDTOs[i] = us.itemMappers.SavedItemToDTO(item, current)
+	}
+
+	return savedDTOs, nil
+}
+
+// refreshPrices re-fetches the lines to buy from the catalog and updates the cart with the current prices.
+func (us *CartUseCaseImpl) refreshPrices(ctx context.Context, cart *domain.Cart, excludeItemsIDs []*uuid.UUID) ([]domain.ItemChange, error) {
+	productIDs := make([]uuid.UUID, len(cart.Items))
+	for i, item := range cart.Items {
+		productIDs[i] = item.ProductID
+	}
+
+	products, err := us.productService.GetProductsByIdIn(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[uuid.UUID]domain.CatalogPrice, len(*products))
+	for _, product := range *products {
+		catalog[product.Id] = domain.CatalogPrice{
+			UnitPrice: product.Price,
+			Discount:  product.Disccount,
+			Available: product.IsAvalaible,
+		}
+	}
+
+	return cart.RefreshPrices(catalog, excludeItemsIDs), nil
+}
+
+func checkExpectedVersion(ctx context.Context, cart *domain.Cart) error {
+	if version, ok := input.ExpectedVersion(ctx); ok {
+		return cart.CheckVersion(version)
+	}
+	return nil
+}
+
+func (us *CartUseCaseImpl) validateCouponRedemption(ctx context.Context, promotion domain.Promotion, userID uuid.UUID) error {
+	// Expired coupons are dropped by Cart.Buy, only the usage limit can block the purchase
+	if !promotion.IsAvailableAt(time.Now()) {
+		return nil
+	}
+
+	timesUsed, err := us.promotionRepository.CountRedemptions(ctx, promotion.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return promotion.ValidateRedemption(timesUsed, time.Now())
+}
+
+// fetchProductData returns the catalog data of the items to add. It fails when one of the products cannot be added.
+func fetchProductData(ctx context.Context, productService facadeService.ProductFacadeService, insertDTOS []dtos.CartItemInserDTO) (*[]dtos.CartItemFetchedDTO, error) {
 	var productData []dtos.CartItemFetchedDTO
 	var failedProducts []uuid.UUID
 
+	productIDs := make([]uuid.UUID, len(insertDTOS))
+	for i, dto := range insertDTOS {
+		productIDs[i] = dto.ProductID
+	}
+
+	productMap, err := fetchProducts(ctx, productService, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, dto := range insertDTOS {
-		product, err := us.productService.GetProductById(dto.ProductID)
-		if err != nil || !product.IsAvalaible {
+		product, found := productMap[dto.ProductID]
+		if !found || !product.IsAvalaible {
 			failedProducts = append(failedProducts, dto.ProductID)
 			continue
 		}
 
 		productData = append(productData, dtos.CartItemFetchedDTO{
 			Quantity:    dto.Quantity,
-			ProductData: *product,
+			ProductData: product,
 		})
 	}
 
 	if len(failedProducts) > 0 {
-		return &productData, fmt.Errorf("products not avalaible: %v", failedProducts)
+		return nil, domain.ErrProductUnavailable.Withf("cart: products not available: %v", failedProducts)
 	}
 
 	return &productData, nil
 }
+
+// fetchProducts reads the products from the catalog in one call, indexed by id. Missing products are left out.
+func fetchProducts(ctx context.Context, productService facadeService.ProductFacadeService, productIDs []uuid.UUID) (map[uuid.UUID]facadeService.Product, error) {
+	if len(productIDs) == 0 {
+		return map[uuid.UUID]facadeService.Product{}, nil
+	}
+
+	products, err := productService.GetProductsByIdIn(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	productMap := make(map[uuid.UUID]facadeService.Product, len(*products))
+	for _, product := range *products {
+		productMap[product.Id] = product
+	}
+
+	return productMap, nil
+}
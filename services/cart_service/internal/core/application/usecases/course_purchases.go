@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/google/uuid"
+)
+
+// validateCourses rejects the course lines that cannot be sold: free courses, which are joined in course_service,
+// and courses userID already owns. Guests own no course, pass uuid.Nil to only check the price.
+func validateCourses(ctx context.Context, courseService facadeService.CourseFacadeService, userID uuid.UUID, items []domain.CartItem) error {
+	for _, item := range items {
+		if !item.IsCourse() {
+			continue
+		}
+
+		course, err := courseService.GetCourseById(ctx, item.ProductID)
+		if err != nil {
+			return err
+		}
+		if course.IsFree {
+			return domain.ErrCourseIsFree.Withf("cart: %s is a free course, enroll in it directly", item.Name)
+		}
+
+		if userID == uuid.Nil {
+			continue
+		}
+
+		owned, err := courseService.IsEnrolled(ctx, item.ProductID, userID)
+		if err != nil {
+			return err
+		}
+		if owned {
+			return domain.ErrCourseAlreadyOwned.Withf("cart: %s is already owned", item.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	carttoken "github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/cart_token"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/google/uuid"
+)
+
+type GuestCartUseCaseImpl struct {
+	guestRepository output.GuestCartRepository
+	cartRepository  output.CartRepository
+	cartUseCase     input.CartUseCase
+	itemMappers     mappers.CartItemMapper
+	cartMappers     mappers.CartMapper
+	productService  facadeService.ProductFacadeService
+	courseService   facadeService.CourseFacadeService
+	signer          *carttoken.Signer
+}
+
+func NewGuestCartUseCase(
+	guestRepository output.GuestCartRepository,
+	cartRepository output.CartRepository,
+	cartUseCase input.CartUseCase,
+	productService facadeService.ProductFacadeService,
+	courseService facadeService.CourseFacadeService,
+	signer *carttoken.Signer) input.GuestCartUseCase {
+	return &GuestCartUseCaseImpl{
+		guestRepository: guestRepository,
+		cartRepository:  cartRepository,
+		cartUseCase:     cartUseCase,
+		productService:  productService,
+		courseService:   courseService,
+		signer:          signer,
+	}
+}
+
+func (us *GuestCartUseCaseImpl) CreateCart(ctx context.Context) (*dtos.GuestCartDTO, error) {
+	cart := domain.NewGuestCart()
+	if err := us.guestRepository.CreateCart(ctx, *cart, us.signer.TTL()); err != nil {
+		return nil, err
+	}
+
+	cartDTO, err := us.cartMappers.DomainToDTO(*cart)
+	if err != nil {
+		return nil, err
+	}
+
+	token, expiresAt := us.signer.Issue(cart.ID, cart.CreatedAt)
+	return &dtos.GuestCartDTO{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		Cart:      *cartDTO,
+	}, nil
+}
+
+func (us *GuestCartUseCaseImpl) GetCart(ctx context.Context, token string) (*dtos.CartDTO, error) {
+	cart, err := us.getCart(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.cartMappers.DomainToDTO(*cart)
+}
+
+func (us *GuestCartUseCaseImpl) AddItems(ctx context.Context, token string, insertDTOS []dtos.CartItemInserDTO) (*dtos.CartDTO, error) {
+	cart, err := us.getCart(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	productData, err := fetchProductData(ctx, us.productService, insertDTOS)
+	if err != nil {
+		return nil, err
+	}
+
+	items := us.itemMappers.ProductToItemList(*productData, cart.ID)
+	if err := validateCourses(ctx, us.courseService, uuid.Nil, items); err != nil {
+		return nil, err
+	}
+
+	if err := cart.AddItems(items); err != nil {
+		return nil, err
+	}
+
+	if err := us.guestRepository.UpdateCart(ctx, *cart); err != nil {
+		return nil, err
+	}
+
+	return us.cartMappers.DomainToDTO(*cart)
+}
+
+func (us *GuestCartUseCaseImpl) RemoveItems(ctx context.Context, token string, itemIDs []uuid.UUID) (*dtos.CartDTO, error) {
+	cart, err := us.getCart(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cart.RemoveItems(itemIDs); err != nil {
+		return nil, err
+	}
+
+	if err := us.guestRepository.UpdateCart(ctx, *cart); err != nil {
+		return nil, err
+	}
+
+	return us.cartMappers.DomainToDTO(*cart)
+}
+
+// MergeCarts moves the guest cart into the cart of the user that just logged in and deletes the guest cart.
+// A user without a cart gets one. If the merge fails the guest cart is kept.
+func (us *GuestCartUseCaseImpl) MergeCarts(ctx context.Context, token string, userID uuid.UUID) (*dtos.CartDTO, error) {
+	guestCart, err := us.getCart(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, _ := us.cartRepository.GetByUserID(ctx, userID)
+	isNewCart := cart == nil
+	if isNewCart {
+		cart = domain.NewCart(userID)
+	}
+
+	if err := validateCourses(ctx, us.courseService, userID, guestCart.Items); err != nil {
+		return nil, err
+	}
+
+	if err := cart.Merge(*guestCart); err != nil {
+		return nil, err
+	}
+
+	if isNewCart {
+		_, err = us.cartRepository.CreateCart(ctx, *cart)
+	} else {
+		_, err = us.cartRepository.UpdateCart(ctx, *cart)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.guestRepository.DeleteCart(ctx, guestCart.ID); err != nil {
+		return nil, err
+	}
+
+	return us.cartUseCase.GetCartByUserId(ctx, userID)
+}
+
+func (us *GuestCartUseCaseImpl) getCart(ctx context.Context, token string) (*domain.Cart, error) {
+	cartID, err := us.signer.Verify(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return us.guestRepository.GetById(ctx, cartID)
+}
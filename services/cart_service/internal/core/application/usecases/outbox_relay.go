@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+// OutboxRelay publishes pending outbox rows and marks them as sent.
+// Delivery is at-least-once: a crash between publishing and MarkSent re-sends the row,
+// so consumers must deduplicate by checkout id.
+// An event that failed maxAttempts times, or that can never be published, is marked dead and skipped from then on.
+type OutboxRelay struct {
+	outboxRepository output.OutboxRepository
+	orderPublisher   output.OrderPublisher
+	cartPublisher    output.CartEventPublisher
+	coursePublisher  output.CoursePurchasePublisher
+	interval         time.Duration
+	batchSize        int
+	maxAttempts      int
+}
+
+// errUnpublishable marks the events no retry can publish: a payload that does not decode or an unknown type.
+var errUnpublishable = errors.New("event cannot be published")
+
+func NewOutboxRelay(
+	outboxRepository output.OutboxRepository,
+	orderPublisher output.OrderPublisher,
+	cartPublisher output.CartEventPublisher,
+	coursePublisher output.CoursePurchasePublisher,
+	interval time.Duration,
+	batchSize int,
+	maxAttempts int) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepository: outboxRepository,
+		orderPublisher:   orderPublisher,
+		cartPublisher:    cartPublisher,
+		coursePublisher:  coursePublisher,
+		interval:         interval,
+		batchSize:        batchSize,
+		maxAttempts:      maxAttempts,
+	}
+}
+
+// Start polls the outbox until ctx is cancelled. Call it in its own goroutine.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.RelayPending(ctx); err != nil {
+			log.Printf("Outbox relay: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes one batch of pending events in creation order.
+// It stops at the first publishing failure so events keep their order, unless the failed event is marked dead:
+// a dead event is left behind and the rest of the batch goes on.
+func (r *OutboxRelay) RelayPending(ctx context.Context) error {
+	events, err := r.outboxRepository.GetPending(ctx, r.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := r.publish(ctx, event); err != nil {
+			if errors.Is(err, errUnpublishable) || event.Attempts+1 >= r.maxAttempts {
+				log.Printf("Outbox relay: event %s is dead after %d attempts: %v", event.ID, event.Attempts+1, err)
+				if markErr := r.outboxRepository.MarkDead(ctx, event.ID, err); markErr != nil {
+					return fmt.Errorf("marking event %s as dead: %w", event.ID, markErr)
+				}
+				continue
+			}
+
+			if markErr := r.outboxRepository.MarkFailed(ctx, event.ID, err); markErr != nil {
+				log.Printf("Outbox relay: marking event %s as failed: %v", event.ID, markErr)
+			}
+			return fmt.Errorf("publishing event %s: %w", event.ID, err)
+		}
+
+		if err := r.outboxRepository.MarkSent(ctx, event.ID); err != nil {
+			return fmt.Errorf("marking event %s as sent: %w", event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, event domain.OutboxEvent) error {
+	switch event.EventType {
+	case domain.OrderCreatedEvent:
+		var checkoutEvent dtos.CheckoutEvent
+		if err := json.Unmarshal(event.Payload, &checkoutEvent); err != nil {
+			return fmt.Errorf("%w: %v", errUnpublishable, err)
+		}
+		return r.orderPublisher.PublishOrder(ctx, checkoutEvent)
+	case domain.CartAbandonedEvent:
+		var abandonedEvent dtos.CartAbandonedEvent
+		if err := json.Unmarshal(event.Payload, &abandonedEvent); err != nil {
+			return fmt.Errorf("%w: %v", errUnpublishable, err)
+		}
+		return r.cartPublisher.PublishCartAbandoned(ctx, abandonedEvent)
+	case domain.CoursesPurchasedEvent:
+		var purchaseEvent dtos.CoursePurchaseEvent
+		if err := json.Unmarshal(event.Payload, &purchaseEvent); err != nil {
+			return fmt.Errorf("%w: %v", errUnpublishable, err)
+		}
+		return r.coursePublisher.PublishCoursesPurchased(ctx, purchaseEvent)
+	default:
+		return fmt.Errorf("%w: unknown event type %s", errUnpublishable, event.EventType)
+	}
+}
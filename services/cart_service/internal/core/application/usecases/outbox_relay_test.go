@@ -0,0 +1,155 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/publisher"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/usecases"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+// fakeOutbox keeps the outbox in creation order, GetPending skips the sent and dead events.
+type fakeOutbox struct {
+	events []domain.OutboxEvent
+	status map[uuid.UUID]string
+}
+
+func newFakeOutbox(events ...domain.OutboxEvent) *fakeOutbox {
+	outbox := &fakeOutbox{status: map[uuid.UUID]string{}}
+	for _, event := range events {
+		outbox.Save(context.Background(), event)
+	}
+	return outbox
+}
+
+func (o *fakeOutbox) Save(ctx context.Context, event domain.OutboxEvent) error {
+	o.events = append(o.events, event)
+	o.status[event.ID] = "PENDING"
+	return nil
+}
+
+func (o *fakeOutbox) GetPending(ctx context.Context, limit int) ([]domain.OutboxEvent, error) {
+	pending := []domain.OutboxEvent{}
+	for _, event := range o.events {
+		if o.status[event.ID] == "PENDING" && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+func (o *fakeOutbox) MarkSent(ctx context.Context, id uuid.UUID) error {
+	o.status[id] = "SENT"
+	return nil
+}
+
+func (o *fakeOutbox) MarkFailed(ctx context.Context, id uuid.UUID, cause error) error {
+	o.recordFailure(id, cause)
+	return nil
+}
+
+func (o *fakeOutbox) MarkDead(ctx context.Context, id uuid.UUID, cause error) error {
+	o.recordFailure(id, cause)
+	o.status[id] = "FAILED"
+	return nil
+}
+
+func (o *fakeOutbox) recordFailure(id uuid.UUID, cause error) {
+	for i := range o.events {
+		if o.events[i].ID == id {
+			o.events[i].Attempts++
+			o.events[i].LastError = cause.Error()
+		}
+	}
+}
+
+func orderEvent(t *testing.T) domain.OutboxEvent {
+	t.Helper()
+
+	checkoutID := uuid.New()
+	payload, err := json.Marshal(dtos.CheckoutEvent{Purchase: dtos.PurchaseDetails{CheckoutID: checkoutID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return domain.NewOutboxEvent(checkoutID, domain.OrderCreatedEvent, payload)
+}
+
+func newTestRelay(outbox *fakeOutbox, orders *publisher.InMemoryOrderPublisher, maxAttempts int) *usecases.OutboxRelay {
+	return usecases.NewOutboxRelay(outbox, orders, nil, nil, time.Second, 10, maxAttempts)
+}
+
+func TestRelayPendingSkipsUnpublishableEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		poison domain.OutboxEvent
+	}{
+		{name: "payload does not decode", poison: domain.NewOutboxEvent(uuid.New(), domain.OrderCreatedEvent, []byte("{"))},
+		{name: "unknown event type", poison: domain.NewOutboxEvent(uuid.New(), "SOMETHING_ELSE", []byte("{}"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := orderEvent(t)
+			outbox := newFakeOutbox(tt.poison, next)
+			orders := publisher.NewInMemoryOrderPublisher()
+
+			if err := newTestRelay(outbox, orders, 5).RelayPending(context.Background()); err != nil {
+				t.Fatalf("RelayPending: %v", err)
+			}
+
+			if outbox.status[tt.poison.ID] != "FAILED" {
+				t.Errorf("poison event is %s, want FAILED", outbox.status[tt.poison.ID])
+			}
+			if outbox.status[next.ID] != "SENT" {
+				t.Errorf("event after the poison one is %s, want SENT", outbox.status[next.ID])
+			}
+			if len(orders.Events()) != 1 {
+				t.Errorf("got %d published orders, want 1", len(orders.Events()))
+			}
+		})
+	}
+}
+
+func TestRelayPendingRetriesUntilMaxAttempts(t *testing.T) {
+	first := orderEvent(t)
+	second := orderEvent(t)
+	outbox := newFakeOutbox(first, second)
+	orders := publisher.NewInMemoryOrderPublisher()
+	orders.FailWith(errors.New("broker down"))
+	relay := newTestRelay(outbox, orders, 3)
+
+	// A transient failure keeps the order: nothing after the failing event is tried
+	for attempt := 1; attempt < 3; attempt++ {
+		if err := relay.RelayPending(context.Background()); err == nil {
+			t.Fatalf("attempt %d: want the publishing error", attempt)
+		}
+		if outbox.status[first.ID] != "PENDING" || outbox.events[1].Attempts != 0 {
+			t.Fatalf("attempt %d: first is %s, second tried %d times", attempt, outbox.status[first.ID], outbox.events[1].Attempts)
+		}
+	}
+
+	// The last allowed attempt marks the event dead, the next one gets its own attempts
+	if err := relay.RelayPending(context.Background()); err == nil {
+		t.Fatalf("want the publishing error of the second event")
+	}
+	if outbox.status[first.ID] != "FAILED" || outbox.events[0].Attempts != 3 {
+		t.Errorf("first is %s after %d attempts, want FAILED after 3", outbox.status[first.ID], outbox.events[0].Attempts)
+	}
+	if outbox.status[second.ID] != "PENDING" || outbox.events[1].Attempts != 1 {
+		t.Errorf("second is %s after %d attempts, want PENDING after 1", outbox.status[second.ID], outbox.events[1].Attempts)
+	}
+
+	orders.FailWith(nil)
+	if err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending: %v", err)
+	}
+	if outbox.status[second.ID] != "SENT" {
+		t.Errorf("second is %s once the broker is back, want SENT", outbox.status[second.ID])
+	}
+}
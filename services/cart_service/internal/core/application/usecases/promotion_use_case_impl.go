@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type PromotionUseCaseImpl struct {
+	repository output.PromotionRepository
+	mapper     mappers.PromotionMapper
+}
+
+func NewPromotionUseCase(repository output.PromotionRepository) input.PromotionUseCase {
+	return &PromotionUseCaseImpl{repository: repository}
+}
+
+func (us *PromotionUseCaseImpl) CreatePromotion(ctx context.Context, insertDTO dtos.PromotionInsertDTO) (*dtos.PromotionDTO, error) {
+	promotion, err := domain.NewPromotion(us.mapper.InsertDTOToDomain(insertDTO))
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := us.repository.GetByCode(ctx, promotion.Code)
+	if existing != nil {
+		return nil, domain.ErrPromotionCodeInUse
+	}
+
+	promotionCreated, err := us.repository.CreatePromotion(ctx, *promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.mapper.DomainToDTO(*promotionCreated), nil
+}
+
+func (us *PromotionUseCaseImpl) UpdatePromotion(ctx context.Context, id uuid.UUID, insertDTO dtos.PromotionInsertDTO) (*dtos.PromotionDTO, error) {
+	promotion, err := us.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := promotion.Update(us.mapper.InsertDTOToDomain(insertDTO)); err != nil {
+		return nil, err
+	}
+
+	promotionUpdated, err := us.repository.UpdatePromotion(ctx, *promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.mapper.DomainToDTO(*promotionUpdated), nil
+}
+
+func (us *PromotionUseCaseImpl) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	return us.repository.DeletePromotion(ctx, id)
+}
+
+func (us *PromotionUseCaseImpl) GetPromotionById(ctx context.Context, id uuid.UUID) (*dtos.PromotionDTO, error) {
+	promotion, err := us.repository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.mapper.DomainToDTO(*promotion), nil
+}
+
+func (us *PromotionUseCaseImpl) GetAllPromotions(ctx context.Context) ([]dtos.PromotionDTO, error) {
+	promotions, err := us.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.mapper.DomainsToDTOs(promotions), nil
+}
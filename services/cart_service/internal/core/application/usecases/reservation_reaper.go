@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+)
+
+// ReservationReaper gives back the inventory holds of checkouts that timed out.
+// Committed checkouts confirm their hold in their transaction, so the holds left are of checkouts that never committed.
+type ReservationReaper struct {
+	inventory output.InventoryReservation
+	interval  time.Duration
+}
+
+func NewReservationReaper(inventory output.InventoryReservation, interval time.Duration) *ReservationReaper {
+	return &ReservationReaper{
+		inventory: inventory,
+		interval:  interval,
+	}
+}
+
+// Start releases expired holds until ctx is cancelled. Call it in its own goroutine.
+func (r *ReservationReaper) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		released, err := r.inventory.ReleaseExpired(ctx, time.Now())
+		if err != nil {
+			log.Printf("Reservation reaper: %v", err)
+		} else if released > 0 {
+			log.Printf("Reservation reaper: released %d expired holds", released)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+)
+
+type UserEventUseCaseImpl struct {
+	cartRepository     output.CartRepository
+	eventRepository    output.CartEventRepository
+	snapshotRepository output.CartSnapshotRepository
+	processedEvents    output.ProcessedEventRepository
+	transactionManager output.TransactionManager
+	mapper             mappers.UserEventMapper
+}
+
+func NewUserEventUseCase(
+	cartRepository output.CartRepository,
+	eventRepository output.CartEventRepository,
+	snapshotRepository output.CartSnapshotRepository,
+	processedEvents output.ProcessedEventRepository,
+	transactionManager output.TransactionManager) input.UserEventUseCase {
+	return &UserEventUseCaseImpl{
+		cartRepository:     cartRepository,
+		eventRepository:    eventRepository,
+		snapshotRepository: snapshotRepository,
+		processedEvents:    processedEvents,
+		transactionManager: transactionManager,
+	}
+}
+
+// HandleUserEvent records the event and applies it in one transaction, so an event is either applied and
+// recorded or neither. Each change also checks the cart state, replaying an event changes nothing.
+func (us *UserEventUseCaseImpl) HandleUserEvent(ctx context.Context, eventDTO dtos.UserEventDTO) error {
+	event, err := us.mapper.DTOToDomain(eventDTO)
+	if err != nil {
+		return err
+	}
+
+	return us.transactionManager.WithTransaction(ctx, func(ctx context.Context) error {
+		isNew, err := us.processedEvents.MarkProcessed(ctx, *event)
+		if err != nil {
+			return err
+		}
+		if !isNew {
+			log.Printf("User events: %s %s already processed", event.Type, event.ID)
+			return nil
+		}
+
+		switch event.Type {
+		case domain.UserActivated:
+			return us.createCart(ctx, *event)
+		case domain.UserDeleted:
+			return us.forgetUser(ctx, *event)
+		case domain.UserBanned:
+			return us.freezeCheckout(ctx, *event)
+		default:
+			return domain.ErrInvalidUserEvent.Withf("user events: unknown event type %q", event.Type)
+		}
+	})
+}
+
+// createCart gives an activated user an empty cart. A user already deleted, when the events arrive out of
+// order, gets no cart.
+func (us *UserEventUseCaseImpl) createCart(ctx context.Context, event domain.UserEvent) error {
+	deleted, err := us.isDeleted(ctx, event)
+	if err != nil || deleted {
+		return err
+	}
+
+	cart, err := us.getCart(ctx, event)
+	if err != nil || cart != nil {
+		return err
+	}
+
+	_, err = us.cartRepository.CreateCart(ctx, *domain.NewCart(event.UserID))
+	return err
+}
+
+// forgetUser deletes the cart and the carts the user shared, and anonymises the cart history.
+func (us *UserEventUseCaseImpl) forgetUser(ctx context.Context, event domain.UserEvent) error {
+	if err := us.cartRepository.DeleteCart(ctx, event.UserID); err != nil && !errors.Is(err, domain.ErrCartNotFound) {
+		return err
+	}
+
+	if err := us.snapshotRepository.DeleteByOwner(ctx, event.UserID); err != nil {
+		return err
+	}
+
+	return us.eventRepository.AnonymizeUser(ctx, event.UserID)
+}
+
+// freezeCheckout blocks the checkout of a banned user. A user without a cart gets a frozen one,
+// so a cart created later cannot be used to buy. A user already deleted gets no cart, like in createCart.
+func (us *UserEventUseCaseImpl) freezeCheckout(ctx context.Context, event domain.UserEvent) error {
+	deleted, err := us.isDeleted(ctx, event)
+	if err != nil || deleted {
+		return err
+	}
+
+	cart, err := us.getCart(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	if cart == nil {
+		cart = domain.NewCart(event.UserID)
+		cart.FreezeCheckout()
+		_, err = us.cartRepository.CreateCart(ctx, *cart)
+		return err
+	}
+
+	if cart.CheckoutFrozen {
+		return nil
+	}
+
+	cart.FreezeCheckout()
+	_, err = us.cartRepository.UpdateCart(ctx, *cart)
+	return err
+}
+
+// isDeleted tells whether the user of event was already deleted, its later events must not bring a cart back.
+func (us *UserEventUseCaseImpl) isDeleted(ctx context.Context, event domain.UserEvent) (bool, error) {
+	return us.processedEvents.HasProcessed(ctx, event.UserID, domain.UserDeleted)
+}
+
+// getCart returns the user's cart, nil when the user has none.
+func (us *UserEventUseCaseImpl) getCart(ctx context.Context, event domain.UserEvent) (*domain.Cart, error) {
+	cart, err := us.cartRepository.GetByUserID(ctx, event.UserID)
+	if errors.Is(err, domain.ErrCartNotFound) {
+		return nil, nil
+	}
+	return cart, err
+}
@@ -0,0 +1,106 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/usecases"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/domain"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/shared/dtos"
+	"github.com/google/uuid"
+)
+
+type fakeProcessedEvents struct {
+	events []domain.UserEvent
+}
+
+func (r *fakeProcessedEvents) MarkProcessed(ctx context.Context, event domain.UserEvent) (bool, error) {
+	for _, processed := range r.events {
+		if processed.ID == event.ID {
+			return false, nil
+		}
+	}
+	r.events = append(r.events, event)
+	return true, nil
+}
+
+func (r *fakeProcessedEvents) HasProcessed(ctx context.Context, userID uuid.UUID, eventType domain.UserEventType) (bool, error) {
+	for _, processed := range r.events {
+		if processed.UserID == userID && processed.Type == eventType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fakeHistory stands for the cart event and snapshot repositories, forgetting a user is all it does.
+type fakeHistory struct{}
+
+func (fakeHistory) Append(ctx context.Context, events []domain.CartEvent) error {
+	return nil
+}
+
+func (fakeHistory) GetByCartId(ctx context.Context, cartID uuid.UUID, until time.Time) ([]domain.CartEvent, error) {
+	return nil, nil
+}
+
+func (fakeHistory) AnonymizeUser(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+func (fakeHistory) CreateSnapshot(ctx context.Context, snapshot domain.CartSnapshot) error {
+	return nil
+}
+
+func (fakeHistory) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.CartSnapshot, error) {
+	return nil, domain.ErrSnapshotNotFound
+}
+
+func (fakeHistory) DeleteByOwner(ctx context.Context, ownerID uuid.UUID) error {
+	return nil
+}
+
+func TestHandleUserEventOutOfOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		events     []domain.UserEventType
+		wantCart   bool
+		wantFrozen bool
+	}{
+		{name: "activated", events: []domain.UserEventType{domain.UserActivated}, wantCart: true},
+		{name: "activated then banned", events: []domain.UserEventType{domain.UserActivated, domain.UserBanned}, wantCart: true, wantFrozen: true},
+		{name: "banned before activated", events: []domain.UserEventType{domain.UserBanned, domain.UserActivated}, wantCart: true, wantFrozen: true},
+		{name: "activated then deleted", events: []domain.UserEventType{domain.UserActivated, domain.UserDeleted}},
+		{name: "activated after deleted", events: []domain.UserEventType{domain.UserDeleted, domain.UserActivated}},
+		{name: "banned after deleted", events: []domain.UserEventType{domain.UserDeleted, domain.UserBanned}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carts := &fakeCarts{}
+			useCase := usecases.NewUserEventUseCase(
+				carts,
+				fakeHistory{},
+				fakeHistory{},
+				&fakeProcessedEvents{},
+				&fakeTransactions{checkouts: &fakeCheckouts{}},
+			)
+
+			userID := uuid.New()
+			for _, eventType := range tt.events {
+				event := dtos.UserEventDTO{EventID: uuid.New(), EventType: string(eventType), UserID: userID, OccurredAt: time.Now()}
+				if err := useCase.HandleUserEvent(context.Background(), event); err != nil {
+					t.Fatalf("%s: %v", eventType, err)
+				}
+			}
+
+			if (carts.cart != nil) != tt.wantCart {
+				t.Fatalf("got cart %+v, want one: %v", carts.cart, tt.wantCart)
+			}
+			if tt.wantCart && carts.cart.CheckoutFrozen != tt.wantFrozen {
+				t.Errorf("got checkout frozen %v, want %v", carts.cart.CheckoutFrozen, tt.wantFrozen)
+			}
+		})
+	}
+}
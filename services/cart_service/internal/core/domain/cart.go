@@ -1,48 +1,148 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
 	"github.com/google/uuid"
 )
 
+// Cart is versioned for optimistic locking: Version is the version the cart had when it was loaded.
+// SavedItems is the saved for later list, see MoveToSaved.
+// Events are the changes made since the cart was loaded, they are stored with the cart.
+// DisplayCurrency is the currency the totals are also shown in, see SetDisplayCurrency.
+// CheckoutFrozen blocks buying, see FreezeCheckout.
 type Cart struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Items     []CartItem
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	Items           []CartItem
+	SavedItems      []CartItem
+	Promotion       *Promotion
+	Version         int
+	Events          []CartEvent
+	DisplayCurrency string
+	CheckoutFrozen  bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func NewCart(userID uuid.UUID) *Cart {
 	return &Cart{
-		ID:        uuid.New(),
-		UserID:    userID,
-		Items:     []CartItem{},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		UserID:     userID,
+		Items:      []CartItem{},
+		SavedItems: []CartItem{},
+		Version:    1,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 }
 
+// NewGuestCart creates a cart for a visitor that is not logged in. Guest carts have no user.
+func NewGuestCart() *Cart {
+	return NewCart(uuid.Nil)
+}
+
+// CheckVersion fails with ErrConcurrentModification when the cart is no longer at the version the client saw.
+func (c *Cart) CheckVersion(expected int) error {
+	if c.Version != expected {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+// IsAbandonedAt tells whether the cart holds items and was not touched for idleFor.
+func (c *Cart) IsAbandonedAt(now time.Time, idleFor time.Duration) bool {
+	return len(c.Items) > 0 && !c.UpdatedAt.After(now.Add(-idleFor))
+}
+
+// FreezeCheckout blocks Buy and BuyProduct, the items can still be changed. Banned users get their checkout frozen.
+func (c *Cart) FreezeCheckout() {
+	c.CheckoutFrozen = true
+	c.updateAction()
+}
+
+func (c *Cart) IsGuest() bool {
+	return c.UserID == uuid.Nil
+}
+
+// AddItem adds item to the cart. An item for a product already in the cart increases that line's quantity.
 func (c *Cart) AddItem(item CartItem) error {
-	if err := c.validateMaxLimitOfItems(); err != nil {
+	if err := validateQuantity(item.Quantity); err != nil {
+		return err
+	}
+
+	if err := c.validateCurrency(item); err != nil {
 		return err
 	}
 
 	for i, existingItem := range c.Items {
 		if existingItem.ProductID == item.ProductID {
-			c.Items[i].Quantity += item.Quantity
+			quantity := existingItem.Quantity + item.Quantity
+			if err := validateMaxQuantity(item, quantity); err != nil {
+				return err
+			}
+
+			c.record(QuantityChanged, c.Items[i], quantity, existingItem.Quantity)
+			c.Items[i].Quantity = quantity
+			c.updateAction()
 			return nil
 		}
 	}
 
+	// Only new lines count towards the items limit
+	if err := c.validateMaxLimitOfItems(); err != nil {
+		return err
+	}
+
+	if err := validateMaxQuantity(item, item.Quantity); err != nil {
+		return err
+	}
+
 	c.Items = append(c.Items, item)
+	c.record(ItemAdded, item, item.Quantity, 0)
 	c.updateAction()
 
 	return nil
 }
 
+// UpdateItemQuantity sets the quantity of a line. maxQuantity is the product limit, 0 means no limit.
+// Quantity must stay above zero, use RemoveItem to drop the line.
+func (c *Cart) UpdateItemQuantity(itemID uuid.UUID, quantity int, maxQuantity int) error {
+	if err := validateQuantity(quantity); err != nil {
+		return ErrInvalidQuantity.Withf("cart: quantity must be greater than zero, remove the item instead")
+	}
+
+	for i, item := range c.Items {
+		if item.ID != itemID {
+			continue
+		}
+
+		item.MaxQuantity = maxQuantity
+		if err := validateMaxQuantity(item, quantity); err != nil {
+			return err
+		}
+
+		c.record(QuantityChanged, c.Items[i], quantity, c.Items[i].Quantity)
+		c.Items[i].Quantity = quantity
+		c.updateAction()
+		return nil
+	}
+
+	return ErrItemNotFound
+}
+
+// GetItem returns the line with the given id.
+func (c *Cart) GetItem(itemID uuid.UUID) (*CartItem, error) {
+	for i := range c.Items {
+		if c.Items[i].ID == itemID {
+			return &c.Items[i], nil
+		}
+	}
+	return nil, ErrItemNotFound
+}
+
 func (c *Cart) RemoveItem(itemID uuid.UUID) error {
 	if err := c.validateNotEmptyCart(); err != nil {
 		return err
@@ -56,11 +156,12 @@ func (c *Cart) RemoveItem(itemID uuid.UUID) error {
 			newItems = append(newItems, item)
 		} else {
 			itemSeen = true
+			c.record(ItemRemoved, item, 0, item.Quantity)
 		}
 	}
 
 	if !itemSeen {
-		return errors.New("item not found")
+		return ErrItemNotFound
 	}
 
 	c.Items = newItems
@@ -69,28 +170,147 @@ func (c *Cart) RemoveItem(itemID uuid.UUID) error {
 	return nil
 }
 
-// Create a struct with data to send to payment and order
-func (c *Cart) Buy(excludeItemsIDs []*uuid.UUID) (float64, error) {
+// Merge moves the items of a guest cart into this cart following the AddItem rules.
+// It is all or nothing: when one item cannot be added the cart is left unchanged.
+func (c *Cart) Merge(guest Cart) error {
+	items := make([]CartItem, len(guest.Items))
+	for i, item := range guest.Items {
+		item.CartID = c.ID
+		items[i] = item
+	}
+
+	if err := c.AddItems(items); err != nil {
+		return fmt.Errorf("cart: cannot merge guest cart: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyCoupon attaches promotion to the cart. timesUsed is how many times the cart owner already redeemed it.
+func (c *Cart) ApplyCoupon(promotion Promotion, timesUsed int, now time.Time) error {
 	if err := c.validateNotEmptyCart(); err != nil {
-		return 0, err
+		return err
+	}
+
+	if err := promotion.ValidateRedemption(timesUsed, now); err != nil {
+		return err
+	}
+
+	if !promotion.SupportsCurrency(c.Currency()) {
+		return ErrCouponNotApplicable.Withf("cart: coupon %s is not valid for %s carts", promotion.Code, c.Currency())
+	}
+
+	meetsMinimum, err := promotion.MeetsMinimum(c.Items)
+	if err != nil {
+		return err
 	}
+	if !meetsMinimum {
+		return ErrCouponNotApplicable.Withf("cart: subtotal must be at least %s to use coupon %s", promotion.MinSubtotal, promotion.Code)
+	}
+
+	c.Promotion = &promotion
+	c.updateAction()
+
+	return nil
+}
 
-	if len(excludeItemsIDs) == 0 {
-		return c.completePurchase()
+func (c *Cart) RemoveCoupon() error {
+	if c.Promotion == nil {
+		return ErrCouponNotApplied
 	}
 
-	filteredItems := c.filterItems(excludeItemsIDs)
-	originalItems := c.Items
+	c.Promotion = nil
+	c.updateAction()
 
-	c.Items = filteredItems
-	subTotal, err := c.calculateTotal()
+	return nil
+}
+
+// PromotionDiscounts returns the per-line discounts of the applied coupon, if it still applies.
+func (c *Cart) PromotionDiscounts(now time.Time) ([]PromotionDiscount, error) {
+	pricing, err := c.Price(now)
 	if err != nil {
-		c.Items = originalItems
-		return 0, err
+		return nil, err
 	}
+	return pricing.PromotionDiscounts, nil
+}
 
-	c.completeCartAction()
-	return subTotal, nil
+// Price runs the items through DefaultPricingPipeline with the applied coupon, if it still applies.
+func (c *Cart) Price(now time.Time) (CartPricing, error) {
+	var promotion *Promotion
+	if c.Promotion != nil && c.Promotion.IsAvailableAt(now) {
+		promotion = c.Promotion
+	}
+	return DefaultPricingPipeline(promotion).Price(c.Items)
+}
+
+// Buy moves the items to purchase out of the cart and returns them as a Checkout snapshot.
+// Items listed in excludeItemsIDs stay in the cart.
+func (c *Cart) Buy(excludeItemsIDs []*uuid.UUID) (*Checkout, error) {
+	if err := c.validateCheckoutAllowed(); err != nil {
+		return nil, err
+	}
+
+	if err := c.validateNotEmptyCart(); err != nil {
+		return nil, err
+	}
+
+	excludeMap := c.createExcludeMap(excludeItemsIDs)
+	purchasedItems := make([]CartItem, 0, len(c.Items))
+	remainingItems := make([]CartItem, 0, len(excludeMap))
+	for _, item := range c.Items {
+		if _, found := excludeMap[item.ID]; found {
+			remainingItems = append(remainingItems, item)
+		} else {
+			purchasedItems = append(purchasedItems, item)
+		}
+	}
+
+	if len(purchasedItems) == 0 {
+		return nil, ErrNothingToBuy
+	}
+
+	checkout, err := NewCheckout(c.ID, c.UserID, purchasedItems)
+	if err != nil {
+		return nil, err
+	}
+	if c.Promotion != nil && c.Promotion.IsAvailableAt(checkout.PurchasedAt) {
+		if err := checkout.ApplyPromotion(*c.Promotion); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range purchasedItems {
+		c.record(CheckedOut, item, 0, item.Quantity)
+		c.Events[len(c.Events)-1].CheckoutID = &checkout.ID
+	}
+
+	c.Items = remainingItems
+	c.Promotion = nil
+	c.updateAction()
+
+	return checkout, nil
+}
+
+// BuyProduct creates a Checkout for a single item. The items already in the cart are left untouched.
+func (c *Cart) BuyProduct(item CartItem) (*Checkout, error) {
+	if err := c.validateCheckoutAllowed(); err != nil {
+		return nil, err
+	}
+
+	if err := validateQuantity(item.Quantity); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxQuantity(item, item.Quantity); err != nil {
+		return nil, err
+	}
+
+	if err := validateItemCurrency(item); err != nil {
+		return nil, err
+	}
+
+	item.CartID = c.ID
+	return NewCheckout(c.ID, c.UserID, []CartItem{item})
 }
 
 func (c *Cart) GetItemCount() int {
@@ -101,46 +321,78 @@ func (c *Cart) GetItems() []CartItem {
 	return c.Items
 }
 
-func (c *Cart) CalculateTotal() (float64, error) {
+// Currency is the currency of the items in the cart, DefaultCurrency while it is empty.
+func (c *Cart) Currency() string {
+	if len(c.Items) == 0 || c.Items[0].UnitPrice.Currency() == "" {
+		return money.DefaultCurrency
+	}
+	return c.Items[0].UnitPrice.Currency()
+}
+
+// CalculateTotal is the price of the items before the coupon, see Price for the details.
+func (c *Cart) CalculateTotal() (money.Money, error) {
 	return c.calculateTotal()
 }
 
+// validateMaxLimitOfItems only counts the cart lines, saved items are not part of the limit.
 func (c *Cart) validateMaxLimitOfItems() error {
 	if len(c.Items) >= 20 {
-		return errors.New("cart: cannot add more than 20 items")
+		return ErrTooManyItems
 	}
 	return nil
 }
 
-func (c *Cart) validateNotEmptyCart() error {
-	if len(c.Items) <= 0 {
-		return errors.New("cart: cart is empty")
+func validateQuantity(quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
 	}
 	return nil
 }
 
-func (c *Cart) clearCart() {
-	c.Items = []CartItem{}
+// validateMaxQuantity also limits a course to one unit, a course is owned once whatever the quantity.
+func validateMaxQuantity(item CartItem, quantity int) error {
+	if item.IsCourse() && quantity > 1 {
+		return ErrCourseQuantity.Withf("cart: %s is a course, it can only be bought once", item.Name)
+	}
+	if item.MaxQuantity > 0 && quantity > item.MaxQuantity {
+		return ErrQuantityLimit.Withf("cart: cannot have more than %d units of %s", item.MaxQuantity, item.Name)
+	}
+	return nil
 }
 
-func (c *Cart) completePurchase() (float64, error) {
-	subTotal, err := c.calculateTotal()
-	if err != nil {
-		return 0, err
+// validateCurrency also rejects an item whose price is not in an ISO 4217 currency, or whose discount is in another one.
+func (c *Cart) validateCurrency(item CartItem) error {
+	if err := validateItemCurrency(item); err != nil {
+		return err
 	}
-	c.completeCartAction()
-	return subTotal, nil
+	if len(c.Items) > 0 && item.UnitPrice.Currency() != c.Currency() {
+		return ErrCurrencyMismatch.Withf("cart: item currency %s does not match cart currency %s", item.UnitPrice.Currency(), c.Currency())
+	}
+	return nil
 }
 
-func (c *Cart) filterItems(excludeItemsIDs []*uuid.UUID) []CartItem {
-	excludeMap := c.createExcludeMap(excludeItemsIDs)
-	filteredItems := make([]CartItem, 0, len(c.Items))
-	for _, item := range c.Items {
-		if _, found := excludeMap[item.ID]; !found {
-			filteredItems = append(filteredItems, item)
-		}
+func validateItemCurrency(item CartItem) error {
+	if err := money.ValidateCurrency(item.UnitPrice.Currency()); err != nil {
+		return ErrUnsupportedCurrency.Withf("cart: %s is priced in %q, which is not a currency", item.Name, item.UnitPrice.Currency())
+	}
+	if !item.Discount.SameCurrency(item.UnitPrice) {
+		return ErrCurrencyMismatch.Withf("cart: %s has its discount in %s and its price in %s", item.Name, item.Discount.Currency(), item.UnitPrice.Currency())
 	}
-	return filteredItems
+	return nil
+}
+
+func (c *Cart) validateNotEmptyCart() error {
+	if len(c.Items) <= 0 {
+		return ErrCartEmpty
+	}
+	return nil
+}
+
+func (c *Cart) validateCheckoutAllowed() error {
+	if c.CheckoutFrozen {
+		return ErrCheckoutFrozen
+	}
+	return nil
 }
 
 func (c *Cart) createExcludeMap(excludeItemsIDs []*uuid.UUID) map[uuid.UUID]bool {
@@ -151,30 +403,39 @@ func (c *Cart) createExcludeMap(excludeItemsIDs []*uuid.UUID) map[uuid.UUID]bool
 	return excludeMap
 }
 
-func (c *Cart) completeCartAction() {
-	c.clearCart()
-	c.updateAction()
-}
-
-func (c *Cart) calculateTotal() (float64, error) {
+func (c *Cart) calculateTotal() (money.Money, error) {
+	total := money.Zero(c.Currency())
 	if err := c.validateNotEmptyCart(); err != nil {
-		return 0, err
+		return total, err
 	}
 
-	total := 0.0
-	for _, item := range c.Items {
-		total += (item.UnitPrice * float64(item.Quantity)) - item.Discount
+	pricing, err := DefaultPricingPipeline(nil).Price(c.Items)
+	if err != nil {
+		return total, err
 	}
-
-	return total, nil
+	return pricing.Total, nil
 }
 
 func (c *Cart) updateAction() {
 	c.UpdatedAt = time.Now()
 }
 
-func (c *Cart) AddItems(items []CartItem) {
-	c.Items = append(c.Items, items...)
+// AddItems adds each item with AddItem. It is all or nothing: when one item fails the cart is left unchanged.
+func (c *Cart) AddItems(items []CartItem) error {
+	updated := *c
+	updated.Items = append([]CartItem{}, c.Items...)
+
+	for _, item := range items {
+		if err := updated.AddItem(item); err != nil {
+			return err
+		}
+	}
+
+	c.Items = updated.Items
+	c.Events = updated.Events
+	c.UpdatedAt = updated.UpdatedAt
+
+	return nil
 }
 
 func (c *Cart) RemoveItems(itemsIds []uuid.UUID) error {
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+type ItemChangeReason string
+
+const (
+	PriceChanged    ItemChangeReason = "PRICE_CHANGED"
+	CurrencyChanged ItemChangeReason = "CURRENCY_CHANGED"
+	ItemUnavailable ItemChangeReason = "UNAVAILABLE"
+)
+
+// CatalogPrice is the current catalog state of a product.
+type CatalogPrice struct {
+	UnitPrice money.Money
+	Discount  money.Money
+	Available bool
+}
+
+// ItemChange describes a cart line whose catalog data moved since it was added.
+type ItemChange struct {
+	ItemID       uuid.UUID
+	ProductID    uuid.UUID
+	Name         string
+	Reason       ItemChangeReason
+	OldUnitPrice money.Money
+	NewUnitPrice money.Money
+	OldDiscount  money.Money
+	NewDiscount  money.Money
+}
+
+// CartChangedError carries the changes the buyer has to acknowledge before buying. It matches ErrCartChanged.
+type CartChangedError struct {
+	Changes []ItemChange
+}
+
+func (e *CartChangedError) Error() string {
+	return ErrCartChanged.Error()
+}
+
+func (e *CartChangedError) Unwrap() error {
+	return ErrCartChanged
+}
+
+// RefreshPrices compares the lines with catalog, keyed by product id, and returns what changed.
+// Lines with a new price in the same currency take it. Unavailable lines, lines missing from the catalog and
+// lines whose currency changed are left as they are: they must be removed or excluded before buying.
+// Lines listed in excludeItemsIDs are not checked.
+func (c *Cart) RefreshPrices(catalog map[uuid.UUID]CatalogPrice, excludeItemsIDs []*uuid.UUID) []ItemChange {
+	excludeMap := c.createExcludeMap(excludeItemsIDs)
+	changes := []ItemChange{}
+
+	for i, item := range c.Items {
+		if excludeMap[item.ID] {
+			continue
+		}
+
+		change := ItemChange{
+			ItemID:       item.ID,
+			ProductID:    item.ProductID,
+			Name:         item.Name,
+			OldUnitPrice: item.UnitPrice,
+			NewUnitPrice: item.UnitPrice,
+			OldDiscount:  item.Discount,
+			NewDiscount:  item.Discount,
+		}
+
+		current, found := catalog[item.ProductID]
+		switch {
+		case !found || !current.Available:
+			change.Reason = ItemUnavailable
+		case !current.UnitPrice.SameCurrency(item.UnitPrice) || !current.Discount.SameCurrency(item.Discount):
+			change.Reason = CurrencyChanged
+			change.NewUnitPrice = current.UnitPrice
+			change.NewDiscount = current.Discount
+		case current.UnitPrice.Amount() != item.UnitPrice.Amount() || current.Discount.Amount() != item.Discount.Amount():
+			change.Reason = PriceChanged
+			change.NewUnitPrice = current.UnitPrice
+			change.NewDiscount = current.Discount
+			c.Items[i].UnitPrice = current.UnitPrice
+			c.Items[i].Discount = current.Discount
+			c.record(ItemPriceChanged, c.Items[i], item.Quantity, item.Quantity)
+		default:
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	if len(changes) > 0 {
+		c.updateAction()
+	}
+
+	return changes
+}
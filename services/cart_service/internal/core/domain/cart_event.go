@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+type CartEventType string
+
+const (
+	ItemAdded        CartEventType = "ITEM_ADDED"
+	ItemRemoved      CartEventType = "ITEM_REMOVED"
+	QuantityChanged  CartEventType = "QUANTITY_CHANGED"
+	ItemPriceChanged CartEventType = "ITEM_PRICE_CHANGED"
+	CartCleared      CartEventType = "CART_CLEARED"
+	CheckedOut       CartEventType = "CHECKED_OUT"
+)
+
+// CartEvent is one change to one cart line. Events are append only, replaying them in order with
+// RebuildCart gives back the lines of the cart. The saved for later list and the coupon are not recorded.
+// Quantity is the quantity of the line after the event, 0 once the line left the cart.
+type CartEvent struct {
+	ID               uint64
+	CartID           uuid.UUID
+	UserID           uuid.UUID
+	Type             CartEventType
+	ItemID           uuid.UUID
+	ProductID        uuid.UUID
+	Name             string
+	UnitPrice        money.Money
+	Discount         money.Money
+	Quantity         int
+	PreviousQuantity int
+	CheckoutID       *uuid.UUID
+	OccurredAt       time.Time
+}
+
+// Clear empties the cart. The saved for later list is kept.
+func (c *Cart) Clear() {
+	for _, item := range c.Items {
+		c.record(CartCleared, item, 0, item.Quantity)
+	}
+
+	c.Items = []CartItem{}
+	c.updateAction()
+}
+
+// RebuildCart replays events, oldest first, into the lines the cart had after the last one.
+func RebuildCart(cartID, userID uuid.UUID, events []CartEvent) *Cart {
+	cart := &Cart{
+		ID:         cartID,
+		UserID:     userID,
+		Items:      []CartItem{},
+		SavedItems: []CartItem{},
+	}
+
+	for _, event := range events {
+		if cart.CreatedAt.IsZero() {
+			cart.CreatedAt = event.OccurredAt
+		}
+		cart.UpdatedAt = event.OccurredAt
+
+		switch event.Type {
+		case ItemAdded:
+			cart.Items = append(cart.Items, CartItem{
+				ID:        event.ItemID,
+				CartID:    cartID,
+				ProductID: event.ProductID,
+				Name:      event.Name,
+				UnitPrice: event.UnitPrice,
+				Quantity:  event.Quantity,
+				Discount:  event.Discount,
+				AddedAt:   event.OccurredAt,
+			})
+		case QuantityChanged, ItemPriceChanged:
+			if item, err := cart.GetItem(event.ItemID); err == nil {
+				item.Quantity = event.Quantity
+				item.UnitPrice = event.UnitPrice
+				item.Discount = event.Discount
+			}
+		case ItemRemoved, CartCleared, CheckedOut:
+			for i, item := range cart.Items {
+				if item.ID == event.ItemID {
+					cart.Items = append(cart.Items[:i:i], cart.Items[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	return cart
+}
+
+func (c *Cart) record(eventType CartEventType, item CartItem, quantity, previousQuantity int) {
+	c.Events = append(c.Events, CartEvent{
+		CartID:           c.ID,
+		UserID:           c.UserID,
+		Type:             eventType,
+		ItemID:           item.ID,
+		ProductID:        item.ProductID,
+		Name:             item.Name,
+		UnitPrice:        item.UnitPrice,
+		Discount:         item.Discount,
+		Quantity:         quantity,
+		PreviousQuantity: previousQuantity,
+		OccurredAt:       time.Now(),
+	})
+}
@@ -3,28 +3,78 @@ package domain
 import (
 	"time"
 
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
 	"github.com/google/uuid"
 )
 
+// ProductType is what a cart line represents. A course line gives access to the course once bought.
+type ProductType string
+
+const (
+	ProductTypePhysical ProductType = "PHYSICAL"
+	ProductTypeCourse   ProductType = "COURSE"
+)
+
+// ParseProductType reads the type reported by the catalog, products without a known type are physical.
+func ParseProductType(productType string) ProductType {
+	if ProductType(productType) == ProductTypeCourse {
+		return ProductTypeCourse
+	}
+	return ProductTypePhysical
+}
+
 type CartItem struct {
-	ID        uuid.UUID
-	CartID    uuid.UUID
-	ProductID uuid.UUID
-	Name      string
-	UnitPrice float64
-	Quantity  int
-	Discount  float64
-	AddedAt   time.Time
+	ID          uuid.UUID
+	CartID      uuid.UUID
+	ProductID   uuid.UUID
+	ProductType ProductType
+	Name        string
+	UnitPrice   money.Money
+	Quantity    int
+	Discount    money.Money
+	// MaxQuantity is the per-product limit the catalog reported when the item was built, 0 means no limit.
+	// It is not stored with the cart.
+	MaxQuantity int
+	AddedAt     time.Time
 }
 
-func NewCartItem(productID uuid.UUID, name string, unitPrice float64, quantity int, discount float64) CartItem {
+func NewCartItem(productID uuid.UUID, name string, unitPrice money.Money, quantity int, discount money.Money) CartItem {
 	return CartItem{
-		ID:        uuid.New(),
-		ProductID: productID,
-		Name:      name,
-		UnitPrice: unitPrice,
-		Quantity:  quantity,
-		Discount:  discount,
-		AddedAt:   time.Now(),
+		ID:          uuid.New(),
+		ProductID:   productID,
+		ProductType: ProductTypePhysical,
+		Name:        name,
+		UnitPrice:   unitPrice,
+		Quantity:    quantity,
+		Discount:    discount,
+		AddedAt:     time.Now(),
+	}
+}
+
+func (i CartItem) IsCourse() bool {
+	return i.ProductType == ProductTypeCourse
+}
+
+// BasePrice is the price of the line before any discount.
+func (i CartItem) BasePrice() money.Money {
+	return i.UnitPrice.Multiply(int64(i.Quantity))
+}
+
+// LineDiscount is the catalog discount of every unit of the line, never more than the line is worth.
+// It fails with ErrCurrencyMismatch when the discount is not in the currency of the price.
+func (i CartItem) LineDiscount() (money.Money, error) {
+	discount, _, err := capDiscount(i.Discount.Multiply(int64(i.Quantity)), i.BasePrice())
+	if err != nil {
+		return money.Money{}, ErrCurrencyMismatch.Withf("cart: %s has its discount in another currency than its price", i.Name)
+	}
+	return discount, nil
+}
+
+// LineTotal is the price of the line after its catalog discount, before any cart promotion.
+func (i CartItem) LineTotal() (money.Money, error) {
+	discount, err := i.LineDiscount()
+	if err != nil {
+		return money.Money{}, err
 	}
+	return i.BasePrice().Sub(discount)
 }
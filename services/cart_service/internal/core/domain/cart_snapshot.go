@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CartSnapshot is a frozen copy of the lines of a cart that can be shared through a token.
+// Only the hash of the token is kept, the token itself is handed out once by NewCartSnapshot.
+// The prices are the ones the owner saw, importing the snapshot prices the items again.
+type CartSnapshot struct {
+	ID        uuid.UUID
+	TokenHash string
+	CartID    uuid.UUID
+	OwnerID   uuid.UUID
+	Items     []CartItem
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewCartSnapshot freezes the lines of cart for ttl and returns the snapshot with its token.
+func NewCartSnapshot(cart Cart, ttl time.Duration, now time.Time) (*CartSnapshot, string, error) {
+	if err := cart.validateNotEmptyCart(); err != nil {
+		return nil, "", err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	snapshotID := uuid.New()
+	items := make([]CartItem, len(cart.Items))
+	for i, item := range cart.Items {
+		item.ID = uuid.New()
+		item.CartID = snapshotID
+		items[i] = item
+	}
+
+	return &CartSnapshot{
+		ID:        snapshotID,
+		TokenHash: HashShareToken(token),
+		CartID:    cart.ID,
+		OwnerID:   cart.UserID,
+		Items:     items,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, token, nil
+}
+
+func (s *CartSnapshot) CheckNotExpired(now time.Time) error {
+	if !now.Before(s.ExpiresAt) {
+		return ErrSnapshotExpired
+	}
+	return nil
+}
+
+// Price prices the shared lines at the prices they were shared with, there is no coupon on a snapshot.
+func (s *CartSnapshot) Price() (CartPricing, error) {
+	return DefaultPricingPipeline(nil).Price(s.Items)
+}
+
+// HashShareToken is how share tokens are stored and looked up.
+func HashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newShareToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
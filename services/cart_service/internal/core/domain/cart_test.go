@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+func newTestCart(items ...CartItem) *Cart {
+	cart := NewCart(uuid.New())
+	for _, item := range items {
+		item.CartID = cart.ID
+		cart.Items = append(cart.Items, item)
+	}
+	return cart
+}
+
+func TestBuyProduct(t *testing.T) {
+	limited := NewCartItem(uuid.New(), "limited", usd(1000), 3, usd(0))
+	limited.MaxQuantity = 2
+
+	course := NewCartItem(uuid.New(), "course", usd(4900), 2, usd(0))
+	course.ProductType = ProductTypeCourse
+
+	tests := []struct {
+		name    string
+		frozen  bool
+		item    CartItem
+		wantErr error
+	}{
+		{name: "buys the item", item: NewCartItem(uuid.New(), "pen", usd(250), 2, usd(50))},
+		{name: "frozen checkout", frozen: true, item: NewCartItem(uuid.New(), "pen", usd(250), 1, usd(0)), wantErr: ErrCheckoutFrozen},
+		{name: "zero quantity", item: NewCartItem(uuid.New(), "pen", usd(250), 0, usd(0)), wantErr: ErrInvalidQuantity},
+		{name: "negative quantity", item: NewCartItem(uuid.New(), "pen", usd(250), -1, usd(0)), wantErr: ErrInvalidQuantity},
+		{name: "over the product limit", item: limited, wantErr: ErrQuantityLimit},
+		{name: "course bought twice", item: course, wantErr: ErrCourseQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := newTestCart(
+				NewCartItem(uuid.New(), "book", usd(1999), 1, usd(0)),
+				NewCartItem(uuid.New(), "mug", usd(800), 2, usd(100)),
+			)
+			if tt.frozen {
+				cart.FreezeCheckout()
+			}
+
+			items := append([]CartItem{}, cart.Items...)
+			version := cart.Version
+
+			checkout, err := cart.BuyProduct(tt.item)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			// Buying a single product never touches the cart, whatever the outcome
+			if !reflect.DeepEqual(cart.Items, items) {
+				t.Errorf("cart items changed: got %+v, want %+v", cart.Items, items)
+			}
+			if len(cart.Events) != 0 || cart.Version != version {
+				t.Errorf("cart recorded %d events and moved to version %d", len(cart.Events), cart.Version)
+			}
+
+			if tt.wantErr != nil {
+				if checkout != nil {
+					t.Errorf("got a checkout along with the error")
+				}
+				return
+			}
+
+			if len(checkout.Items) != 1 || checkout.Items[0].ProductID != tt.item.ProductID {
+				t.Fatalf("got checkout items %+v, want only %s", checkout.Items, tt.item.Name)
+			}
+			if checkout.Items[0].CartID != cart.ID || checkout.CartID != cart.ID || checkout.UserID != cart.UserID {
+				t.Errorf("checkout is not tied to the cart")
+			}
+			lineTotal, err := tt.item.LineTotal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !checkout.Total.Equals(lineTotal) {
+				t.Errorf("got total %s, want %s", checkout.Total, lineTotal)
+			}
+		})
+	}
+}
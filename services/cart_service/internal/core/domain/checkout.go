@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+// Checkout is the frozen snapshot of the items a user bought from their cart.
+type Checkout struct {
+	ID                 uuid.UUID
+	CartID             uuid.UUID
+	UserID             uuid.UUID
+	Items              []CartItem
+	PromotionID        *uuid.UUID
+	CouponCode         string
+	PromotionDiscounts []PromotionDiscount
+	SubTotal           money.Money
+	TotalDiscount      money.Money
+	PromotionDiscount  money.Money
+	Total              money.Money
+	DisplayCurrency    string
+	ExchangeRate       *ExchangeRate
+	PurchasedAt        time.Time
+}
+
+// NewCheckout fails with ErrCurrencyMismatch when the items are not all in the same currency.
+func NewCheckout(cartID, userID uuid.UUID, items []CartItem) (*Checkout, error) {
+	checkout := &Checkout{
+		ID:          uuid.New(),
+		CartID:      cartID,
+		UserID:      userID,
+		Items:       items,
+		PurchasedAt: time.Now(),
+	}
+
+	pricing, err := DefaultPricingPipeline(nil).Price(items)
+	if err != nil {
+		return nil, err
+	}
+
+	checkout.applyPricing(pricing)
+	return checkout, nil
+}
+
+// ApplyPromotion discounts the checkout with the coupon the cart carried.
+func (c *Checkout) ApplyPromotion(promotion Promotion) error {
+	pricing, err := DefaultPricingPipeline(&promotion).Price(c.Items)
+	if err != nil {
+		return err
+	}
+	if len(pricing.PromotionDiscounts) == 0 {
+		return nil
+	}
+
+	c.PromotionID = &promotion.ID
+	c.CouponCode = promotion.Code
+	c.applyPricing(pricing)
+	return nil
+}
+
+// CourseIDs lists the courses bought in the checkout, the buyer is enrolled in them once the checkout is committed.
+func (c *Checkout) CourseIDs() []uuid.UUID {
+	courseIDs := []uuid.UUID{}
+	for _, item := range c.Items {
+		if item.IsCourse() {
+			courseIDs = append(courseIDs, item.ProductID)
+		}
+	}
+	return courseIDs
+}
+
+func (c *Checkout) GetItemCount() int {
+	return len(c.Items)
+}
+
+// applyPricing sets the totals from a pipeline run. The rounding and floor corrections are only part of Total.
+func (c *Checkout) applyPricing(pricing CartPricing) {
+	c.PromotionDiscounts = pricing.PromotionDiscounts
+	c.SubTotal = pricing.SubTotal
+	c.TotalDiscount = pricing.ItemDiscount
+	c.PromotionDiscount = pricing.PromotionDiscount
+	c.Total = pricing.Total
+}
@@ -0,0 +1,107 @@
+package domain
+
+import "fmt"
+
+// ErrorKind classifies a DomainError so the adapters can answer it without knowing every error.
+type ErrorKind int
+
+const (
+	// KindInvalid is a request that can never succeed as sent, like a zero quantity.
+	KindInvalid ErrorKind = iota
+	// KindNotFound is a cart, line or resource that does not exist.
+	KindNotFound
+	// KindConflict is a request that clashes with the current state, like a stale cart version.
+	KindConflict
+	// KindRuleViolation is a valid request the cart rules reject in its current state, like buying an empty cart.
+	KindRuleViolation
+	// KindLimitExceeded is a request that goes over a limit of the cart, a product or a coupon.
+	KindLimitExceeded
+	// KindUnavailable is a product or stock that cannot be sold right now.
+	KindUnavailable
+	// KindExpired is a resource that existed but can no longer be used.
+	KindExpired
+	// KindForbidden is a request the user is not allowed to make anymore, like a banned user buying.
+	KindForbidden
+)
+
+// DomainError is an error of the cart rules with a stable, machine readable Code.
+// Errors with details are built from the ones below with Withf so errors.Is still matches them.
+type DomainError struct {
+	Code    string
+	Kind    ErrorKind
+	Message string
+	Err     error
+}
+
+func (e *DomainError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches any DomainError with the same code, see Withf.
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
+// Withf returns a copy of e with a message naming the details of this occurrence.
+func (e *DomainError) Withf(format string, args ...any) *DomainError {
+	return NewDomainError(e.Code, e.Kind, fmt.Sprintf(format, args...), e.Err)
+}
+
+func NewDomainError(code string, kind ErrorKind, message string, err error) *DomainError {
+	return &DomainError{
+		Code:    code,
+		Kind:    kind,
+		Message: message,
+		Err:     err,
+	}
+}
+
+var (
+	ErrCartNotFound           = NewDomainError("CART_NOT_FOUND", KindNotFound, "cart: cart not found", nil)
+	ErrCartAlreadyExists      = NewDomainError("CART_ALREADY_EXISTS", KindConflict, "cart: user already has a cart", nil)
+	ErrConcurrentModification = NewDomainError("CART_VERSION_CONFLICT", KindConflict, "cart: cart was modified by another request", nil)
+	ErrCartChanged            = NewDomainError("CART_CHANGED", KindConflict, "cart: prices or availability changed since the items were added", nil)
+	ErrCartEmpty              = NewDomainError("CART_EMPTY", KindRuleViolation, "cart: cart is empty", nil)
+	ErrNothingToBuy           = NewDomainError("CART_NOTHING_TO_BUY", KindRuleViolation, "cart: no items left to buy", nil)
+	ErrTooManyItems           = NewDomainError("CART_ITEMS_LIMIT", KindLimitExceeded, "cart: cannot add more than 20 items", nil)
+	ErrCurrencyMismatch       = NewDomainError("CURRENCY_MISMATCH", KindRuleViolation, "cart: currency mismatch", nil)
+	ErrUnsupportedCurrency    = NewDomainError("UNSUPPORTED_CURRENCY", KindInvalid, "cart: currency is not supported", nil)
+	ErrCartHistoryNotFound    = NewDomainError("CART_HISTORY_NOT_FOUND", KindNotFound, "cart: no history recorded for this cart", nil)
+	ErrGuestCartNotFound      = NewDomainError("GUEST_CART_NOT_FOUND", KindNotFound, "cart: guest cart not found or expired", nil)
+	ErrCheckoutFrozen         = NewDomainError("CHECKOUT_FROZEN", KindForbidden, "cart: checkout is frozen for this account", nil)
+
+	ErrItemNotFound       = NewDomainError("ITEM_NOT_FOUND", KindNotFound, "cart: item not found", nil)
+	ErrSavedItemNotFound  = NewDomainError("SAVED_ITEM_NOT_FOUND", KindNotFound, "cart: saved item not found", nil)
+	ErrInvalidQuantity    = NewDomainError("INVALID_QUANTITY", KindInvalid, "cart: quantity must be greater than zero", nil)
+	ErrQuantityLimit      = NewDomainError("QUANTITY_LIMIT_EXCEEDED", KindLimitExceeded, "cart: quantity limit exceeded", nil)
+	ErrProductUnavailable = NewDomainError("PRODUCT_UNAVAILABLE", KindUnavailable, "cart: product is not available", nil)
+
+	ErrCourseQuantity     = NewDomainError("COURSE_QUANTITY_LIMIT", KindLimitExceeded, "cart: a course can only be bought once", nil)
+	ErrCourseAlreadyOwned = NewDomainError("COURSE_ALREADY_OWNED", KindConflict, "cart: course is already owned", nil)
+	ErrCourseIsFree       = NewDomainError("COURSE_IS_FREE", KindRuleViolation, "cart: free courses are not sold through the cart", nil)
+
+	ErrSnapshotNotFound = NewDomainError("SHARED_CART_NOT_FOUND", KindNotFound, "cart: shared cart not found", nil)
+	ErrSnapshotExpired  = NewDomainError("SHARED_CART_EXPIRED", KindExpired, "cart: shared cart has expired", nil)
+
+	ErrCouponNotFound      = NewDomainError("COUPON_NOT_FOUND", KindNotFound, "promotion: coupon not found", nil)
+	ErrCouponNotApplied    = NewDomainError("COUPON_NOT_APPLIED", KindRuleViolation, "cart: no coupon applied", nil)
+	ErrCouponNotApplicable = NewDomainError("COUPON_NOT_APPLICABLE", KindRuleViolation, "cart: coupon does not apply to this cart", nil)
+	ErrCouponUnavailable   = NewDomainError("COUPON_UNAVAILABLE", KindExpired, "promotion: coupon is expired or not active", nil)
+	ErrCouponUsageLimit    = NewDomainError("COUPON_USAGE_LIMIT", KindLimitExceeded, "promotion: coupon usage limit reached", nil)
+	ErrPromotionNotFound   = NewDomainError("PROMOTION_NOT_FOUND", KindNotFound, "promotion: promotion not found", nil)
+	ErrPromotionCodeInUse  = NewDomainError("PROMOTION_CODE_IN_USE", KindConflict, "promotion: code already in use", nil)
+	ErrPromotionInvalid    = NewDomainError("PROMOTION_INVALID", KindInvalid, "promotion: invalid promotion", nil)
+
+	ErrInvalidUserEvent = NewDomainError("INVALID_USER_EVENT", KindInvalid, "user events: invalid event", nil)
+
+	ErrInsufficientStock   = NewDomainError("INSUFFICIENT_STOCK", KindUnavailable, "inventory: not enough stock", nil)
+	ErrReservationNotFound = NewDomainError("RESERVATION_NOT_FOUND", KindNotFound, "inventory: reservation not found or expired", nil)
+)
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+)
+
+// ExchangeRate is how many units of Quote one unit of Base is worth, as published at AsOf.
+type ExchangeRate struct {
+	Base  string
+	Quote string
+	Rate  float64
+	AsOf  time.Time
+}
+
+// IdentityRate converts a currency to itself.
+func IdentityRate(currency string, asOf time.Time) ExchangeRate {
+	return ExchangeRate{Base: currency, Quote: currency, Rate: 1, AsOf: asOf}
+}
+
+func (r ExchangeRate) Convert(amount money.Money) money.Money {
+	return amount.Convert(r.Rate, r.Quote)
+}
+
+// SetDisplayCurrency sets the currency the cart totals are also shown in. An empty currency shows the cart currency only.
+// Prices and the checkout stay in the cart currency.
+func (c *Cart) SetDisplayCurrency(currency string) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency != "" && len(currency) != 3 {
+		return ErrUnsupportedCurrency
+	}
+
+	c.DisplayCurrency = currency
+	c.updateAction()
+	return nil
+}
+
+// NeedsConversion tells whether the totals must also be shown in another currency than the cart one.
+func (c *Cart) NeedsConversion() bool {
+	return c.DisplayCurrency != "" && c.DisplayCurrency != c.Currency()
+}
+
+// LockExchangeRate records the rate the buyer saw the total in. It is stored with the checkout so
+// the display total can be explained later, whatever the rate becomes.
+func (c *Checkout) LockExchangeRate(rate ExchangeRate) {
+	c.DisplayCurrency = rate.Quote
+	c.ExchangeRate = &rate
+}
+
+// DisplayTotal is the total in the locked display currency, nil when no rate was locked.
+func (c *Checkout) DisplayTotal() *money.Money {
+	if c.ExchangeRate == nil {
+		return nil
+	}
+	total := c.ExchangeRate.Convert(c.Total)
+	return &total
+}
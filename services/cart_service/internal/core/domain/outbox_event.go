@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderCreatedEvent     = "ORDER_CREATED"
+	CartAbandonedEvent    = "CART_ABANDONED"
+	CoursesPurchasedEvent = "COURSES_PURCHASED"
+)
+
+// OutboxEvent is a message waiting to be relayed to the broker.
+// It is stored in the same transaction as the state change that produced it.
+type OutboxEvent struct {
+	ID          uuid.UUID
+	AggregateID uuid.UUID
+	EventType   string
+	Payload     []byte
+	Attempts    int
+	LastError   string
+	CreatedAt   time.Time
+	SentAt      *time.Time
+}
+
+func NewOutboxEvent(aggregateID uuid.UUID, eventType string, payload []byte) OutboxEvent {
+	return OutboxEvent{
+		ID:          uuid.New(),
+		AggregateID: aggregateID,
+		EventType:   eventType,
+		Payload:     payload,
+		CreatedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,334 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+)
+
+// Steps of the pricing pipeline, in the order DefaultPricingPipeline runs them.
+const (
+	PricingBasePrice    = "BASE_PRICE"
+	PricingItemDiscount = "ITEM_DISCOUNT"
+	PricingPromotion    = "PROMOTION"
+	PricingRounding     = "ROUNDING"
+	PricingFloor        = "FLOOR_AT_ZERO"
+)
+
+// PriceAdjustment is one step of the derivation of a line price.
+// Amount is what the step added to the line, discounts are negative.
+type PriceAdjustment struct {
+	Step        string
+	Amount      money.Money
+	Description string
+}
+
+// PricedLine is a cart line with the price the pipeline derived for it.
+// Explanation lists the steps that priced the line, Total is the sum of their amounts.
+// The rounding and floor steps are always listed, with a zero amount when they changed nothing.
+type PricedLine struct {
+	Item              CartItem
+	BasePrice         money.Money
+	ItemDiscount      money.Money
+	PromotionDiscount money.Money
+	Adjustment        money.Money
+	Total             money.Money
+	Explanation       []PriceAdjustment
+}
+
+// CartPricing is the result of a pipeline run. SubTotal is the sum of the base prices and
+// Adjustment the sum of the rounding and floor corrections:
+// Total = SubTotal - ItemDiscount - PromotionDiscount + Adjustment.
+type CartPricing struct {
+	Lines              []PricedLine
+	PromotionDiscounts []PromotionDiscount
+	SubTotal           money.Money
+	ItemDiscount       money.Money
+	PromotionDiscount  money.Money
+	Adjustment         money.Money
+	Total              money.Money
+}
+
+// PricingStep is one stage of a PricingPipeline. Steps change the lines through PricedLine.adjust
+// so every change ends up in the explanation of the line.
+type PricingStep func(pricing *CartPricing) error
+
+// PricingPipeline runs its steps in order over the lines of a cart.
+type PricingPipeline struct {
+	steps []PricingStep
+}
+
+func NewPricingPipeline(steps ...PricingStep) PricingPipeline {
+	return PricingPipeline{steps: steps}
+}
+
+// DefaultPricingPipeline prices the lines from their base price, takes off the item discounts then the cart promotion,
+// rounds and floors every line at zero. promotion is nil when the cart has none.
+func DefaultPricingPipeline(promotion *Promotion) PricingPipeline {
+	return NewPricingPipeline(
+		BasePriceStep,
+		ItemDiscountStep,
+		PromotionStep(promotion),
+		RoundingStep(1),
+		FloorAtZeroStep,
+	)
+}
+
+// Price fails with ErrCurrencyMismatch when the items are not all in the same currency.
+func (p PricingPipeline) Price(items []CartItem) (CartPricing, error) {
+	pricing := CartPricing{Lines: make([]PricedLine, len(items))}
+	for i, item := range items {
+		zero := money.Zero(item.UnitPrice.Currency())
+		pricing.Lines[i] = PricedLine{
+			Item:              item,
+			BasePrice:         zero,
+			ItemDiscount:      zero,
+			PromotionDiscount: zero,
+			Adjustment:        zero,
+			Total:             zero,
+		}
+	}
+
+	for _, step := range p.steps {
+		if err := step(&pricing); err != nil {
+			return CartPricing{}, err
+		}
+	}
+
+	if err := pricing.sumLines(); err != nil {
+		return CartPricing{}, err
+	}
+	return pricing, nil
+}
+
+// BasePriceStep prices each line at its unit price times its quantity.
+func BasePriceStep(pricing *CartPricing) error {
+	for i := range pricing.Lines {
+		line := &pricing.Lines[i]
+		line.BasePrice = line.Item.BasePrice()
+		if err := line.adjust(PricingBasePrice, line.BasePrice, fmt.Sprintf("%d x %s", line.Item.Quantity, line.Item.UnitPrice)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ItemDiscountStep takes the catalog discount off every unit of the line. It never takes off more than the line is worth.
+func ItemDiscountStep(pricing *CartPricing) error {
+	for i := range pricing.Lines {
+		line := &pricing.Lines[i]
+		if !line.Item.Discount.IsPositive() {
+			continue
+		}
+
+		discount, capped, err := capDiscount(line.Item.Discount.Multiply(int64(line.Item.Quantity)), line.Total)
+		if err != nil {
+			return ErrCurrencyMismatch.Withf("cart: %s has its discount in another currency than its price", line.Item.Name)
+		}
+		if !discount.IsPositive() {
+			continue
+		}
+
+		description := fmt.Sprintf("%s off each of %d units", line.Item.Discount, line.Item.Quantity)
+		if capped {
+			description += ", capped at the line price"
+		}
+
+		if err := line.discount(&line.ItemDiscount, PricingItemDiscount, discount, description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PromotionStep takes the share of promotion of each line off it, on the prices left by the previous steps.
+// A share is capped at what is left of the line. Without a promotion the step changes nothing.
+func PromotionStep(promotion *Promotion) PricingStep {
+	return func(pricing *CartPricing) error {
+		if promotion == nil {
+			return nil
+		}
+
+		items := make([]CartItem, len(pricing.Lines))
+		for i, line := range pricing.Lines {
+			items[i] = line.Item
+		}
+
+		discounts, err := promotion.CalculateDiscounts(items)
+		if err != nil {
+			return err
+		}
+
+		for _, discount := range discounts {
+			line := pricing.line(discount)
+			if line == nil {
+				continue
+			}
+
+			amount, capped, err := capDiscount(discount.Amount, line.Total)
+			if err != nil {
+				return ErrCurrencyMismatch.Withf("cart: coupon %s is not valid for %s carts", discount.Code, line.Total.Currency())
+			}
+			if !amount.IsPositive() {
+				continue
+			}
+
+			description := fmt.Sprintf("coupon %s", discount.Code)
+			if capped {
+				description += ", capped at the line price"
+			}
+
+			discount.Amount = amount
+			pricing.PromotionDiscounts = append(pricing.PromotionDiscounts, discount)
+			if err := line.discount(&line.PromotionDiscount, PricingPromotion, amount, description); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// RoundingStep rounds each line total half away from zero to a multiple of increment minor units,
+// 5 rounds USD lines to the nearest 0.05. Amounts are whole minor units, so an increment of 1 never changes a line.
+func RoundingStep(increment int64) PricingStep {
+	if increment < 1 {
+		increment = 1
+	}
+
+	return func(pricing *CartPricing) error {
+		for i := range pricing.Lines {
+			line := &pricing.Lines[i]
+			remainder := line.Total.Amount() % increment
+			if remainder < 0 {
+				remainder += increment
+			}
+
+			difference := -remainder
+			if remainder*2 >= increment {
+				difference = increment - remainder
+			}
+
+			step := money.New(increment, line.Total.Currency())
+			description := fmt.Sprintf("rounded to %s", step)
+			if difference == 0 {
+				description = fmt.Sprintf("already a multiple of %s", step)
+			}
+
+			if err := line.correct(PricingRounding, money.New(difference, line.Total.Currency()), description); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// FloorAtZeroStep raises a negative line to zero, a line never pays the buyer.
+func FloorAtZeroStep(pricing *CartPricing) error {
+	for i := range pricing.Lines {
+		line := &pricing.Lines[i]
+		adjustment, description := money.Zero(line.Total.Currency()), "not below zero"
+		if line.Total.IsNegative() {
+			adjustment, description = line.Total.Negate(), "price floored at zero"
+		}
+
+		if err := line.correct(PricingFloor, adjustment, description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *PricedLine) adjust(step string, amount money.Money, description string) error {
+	total, err := l.Total.Add(amount)
+	if err != nil {
+		return ErrCurrencyMismatch.Withf("cart: %s cannot be priced in %s", l.Item.Name, amount.Currency())
+	}
+
+	l.Total = total
+	l.Explanation = append(l.Explanation, PriceAdjustment{
+		Step:        step,
+		Amount:      amount,
+		Description: description,
+	})
+	return nil
+}
+
+// discount takes amount off the line and adds it to the discount it belongs to.
+func (l *PricedLine) discount(sum *money.Money, step string, amount money.Money, description string) error {
+	return l.record(sum, step, amount, amount.Negate(), description)
+}
+
+// correct adds a rounding or floor adjustment to the line.
+func (l *PricedLine) correct(step string, amount money.Money, description string) error {
+	return l.record(&l.Adjustment, step, amount, amount, description)
+}
+
+func (l *PricedLine) record(sum *money.Money, step string, amount money.Money, change money.Money, description string) error {
+	total, err := sum.Add(amount)
+	if err != nil {
+		return ErrCurrencyMismatch.Withf("cart: %s cannot be priced in %s", l.Item.Name, amount.Currency())
+	}
+	if err := l.adjust(step, change, description); err != nil {
+		return err
+	}
+	*sum = total
+	return nil
+}
+
+func (p *CartPricing) line(discount PromotionDiscount) *PricedLine {
+	for i := range p.Lines {
+		if p.Lines[i].Item.ID == discount.ItemID {
+			return &p.Lines[i]
+		}
+	}
+	return nil
+}
+
+func (p *CartPricing) sumLines() error {
+	currency := ""
+	if len(p.Lines) > 0 {
+		currency = p.Lines[0].Total.Currency()
+	}
+
+	p.SubTotal = money.Zero(currency)
+	p.ItemDiscount = money.Zero(currency)
+	p.PromotionDiscount = money.Zero(currency)
+	p.Adjustment = money.Zero(currency)
+	p.Total = money.Zero(currency)
+	for _, line := range p.Lines {
+		sums := []struct {
+			sum  *money.Money
+			line money.Money
+		}{
+			{&p.SubTotal, line.BasePrice},
+			{&p.ItemDiscount, line.ItemDiscount},
+			{&p.PromotionDiscount, line.PromotionDiscount},
+			{&p.Adjustment, line.Adjustment},
+			{&p.Total, line.Total},
+		}
+		for _, s := range sums {
+			sum, err := s.sum.Add(s.line)
+			if err != nil {
+				return ErrCurrencyMismatch.Withf("cart: %s is not in the currency of the other items", line.Item.Name)
+			}
+			*s.sum = sum
+		}
+	}
+	return nil
+}
+
+// capDiscount limits discount to what is left of a line, it reports whether it had to.
+func capDiscount(discount money.Money, lineTotal money.Money) (money.Money, bool, error) {
+	if !lineTotal.IsPositive() {
+		return money.Zero(lineTotal.Currency()), discount.IsPositive(), nil
+	}
+
+	cmp, err := discount.Compare(lineTotal)
+	if err != nil {
+		return money.Money{}, false, err
+	}
+	if cmp > 0 {
+		return lineTotal, true, nil
+	}
+	return discount, false, nil
+}
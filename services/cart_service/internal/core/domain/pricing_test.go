@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+func TestDefaultPricingPipeline(t *testing.T) {
+	pen := NewCartItem(uuid.New(), "pen", usd(250), 2, usd(0))
+	mugs := NewCartItem(uuid.New(), "mug", usd(800), 3, usd(100))
+	book := NewCartItem(uuid.New(), "book", usd(1999), 1, usd(0))
+	clearance := NewCartItem(uuid.New(), "clearance", usd(500), 2, usd(700))
+	notebooks := NewCartItem(uuid.New(), "notebook", usd(400), 3, usd(0))
+	eurBook := NewCartItem(uuid.New(), "livre", money.New(1500, "EUR"), 1, money.Zero("EUR"))
+	eurDiscount := NewCartItem(uuid.New(), "pen", usd(250), 1, money.New(50, "EUR"))
+
+	tests := []struct {
+		name       string
+		items      []CartItem
+		promotion  *Promotion
+		wantLines  []int64
+		wantSub    int64
+		wantItem   int64
+		wantPromo  int64
+		wantTotal  int64
+		wantCapped bool
+		wantErr    error
+	}{
+		{
+			name:      "base price",
+			items:     []CartItem{pen},
+			wantLines: []int64{500}, wantSub: 500, wantTotal: 500,
+		},
+		{
+			name:      "discount taken off every unit",
+			items:     []CartItem{mugs},
+			wantLines: []int64{2100}, wantSub: 2400, wantItem: 300, wantTotal: 2100,
+		},
+		{
+			name:      "discount larger than the price",
+			items:     []CartItem{clearance, pen},
+			wantLines: []int64{0, 500}, wantSub: 1500, wantItem: 1000, wantTotal: 500, wantCapped: true,
+		},
+		{
+			name:      "percentage promotion on discounted lines",
+			items:     []CartItem{book, mugs},
+			promotion: &Promotion{Code: "TEN", Type: PercentageOff, Percentage: 10},
+			wantLines: []int64{1799, 1890}, wantSub: 4399, wantItem: 300, wantPromo: 410, wantTotal: 3689,
+		},
+		{
+			name:      "promotion larger than the subtotal",
+			items:     []CartItem{book, pen},
+			promotion: &Promotion{Code: "BIG", Type: FixedAmountOff, Amount: usd(5000)},
+			wantLines: []int64{0, 0}, wantSub: 2499, wantPromo: 2499, wantTotal: 0,
+		},
+		{
+			name:      "promotion after a discount larger than the price",
+			items:     []CartItem{clearance, pen},
+			promotion: &Promotion{Code: "OFF3", Type: FixedAmountOff, Amount: usd(300)},
+			wantLines: []int64{0, 200}, wantSub: 1500, wantItem: 1000, wantPromo: 300, wantTotal: 200, wantCapped: true,
+		},
+		{
+			name:      "buy 2 get 1",
+			items:     []CartItem{notebooks},
+			promotion: &Promotion{Code: "B2G1", Type: BuyXGetY, BuyQuantity: 2, FreeQuantity: 1},
+			wantLines: []int64{800}, wantSub: 1200, wantPromo: 400, wantTotal: 800,
+		},
+		{
+			name:      "promotion in another currency gives nothing",
+			items:     []CartItem{pen},
+			promotion: &Promotion{Code: "EURO", Type: FixedAmountOff, Amount: money.New(100, "EUR")},
+			wantLines: []int64{500}, wantSub: 500, wantTotal: 500,
+		},
+		{
+			name:    "items in mixed currencies",
+			items:   []CartItem{pen, eurBook},
+			wantErr: ErrCurrencyMismatch,
+		},
+		{
+			name:      "items in mixed currencies with a promotion",
+			items:     []CartItem{pen, eurBook},
+			promotion: &Promotion{Code: "TEN", Type: PercentageOff, Percentage: 10},
+			wantErr:   ErrCurrencyMismatch,
+		},
+		{
+			name:    "discount in another currency than the price",
+			items:   []CartItem{eurDiscount},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing, err := DefaultPricingPipeline(tt.promotion).Price(tt.items)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			sums := []struct {
+				name string
+				got  money.Money
+				want int64
+			}{
+				{"subtotal", pricing.SubTotal, tt.wantSub},
+				{"item discount", pricing.ItemDiscount, tt.wantItem},
+				{"promotion discount", pricing.PromotionDiscount, tt.wantPromo},
+				{"adjustment", pricing.Adjustment, 0},
+				{"total", pricing.Total, tt.wantTotal},
+			}
+			for _, sum := range sums {
+				if !sum.got.Equals(usd(sum.want)) {
+					t.Errorf("got %s %s, want %s", sum.name, sum.got, usd(sum.want))
+				}
+			}
+
+			capped := false
+			for i, line := range pricing.Lines {
+				if !line.Total.Equals(usd(tt.wantLines[i])) {
+					t.Errorf("%s: got total %s, want %s", line.Item.Name, line.Total, usd(tt.wantLines[i]))
+				}
+
+				// The explanation adds up to the line total, which never goes below zero
+				explained := money.Zero("USD")
+				for _, adjustment := range line.Explanation {
+					if explained, err = explained.Add(adjustment.Amount); err != nil {
+						t.Fatal(err)
+					}
+					if strings.HasSuffix(adjustment.Description, "capped at the line price") {
+						capped = true
+					}
+				}
+				if steps := explainedSteps(line); len(steps) < 2 || steps[len(steps)-2] != PricingRounding || steps[len(steps)-1] != PricingFloor {
+					t.Errorf("%s: got steps %v, want rounding then floor last", line.Item.Name, steps)
+				}
+				if !explained.Equals(line.Total) {
+					t.Errorf("%s: explanation adds up to %s, want %s", line.Item.Name, explained, line.Total)
+				}
+				if line.Total.IsNegative() {
+					t.Errorf("%s: line total %s is negative", line.Item.Name, line.Total)
+				}
+			}
+			if capped != tt.wantCapped {
+				t.Errorf("got a capped discount %v, want %v", capped, tt.wantCapped)
+			}
+		})
+	}
+}
+
+func explainedSteps(line PricedLine) []string {
+	steps := make([]string, len(line.Explanation))
+	for i, adjustment := range line.Explanation {
+		steps[i] = adjustment.Step
+	}
+	return steps
+}
+
+// uncappedDiscountStep takes the whole catalog discount off every unit, even past the line price.
+func uncappedDiscountStep(pricing *CartPricing) error {
+	for i := range pricing.Lines {
+		line := &pricing.Lines[i]
+		if err := line.discount(&line.ItemDiscount, PricingItemDiscount, line.Item.Discount.Multiply(int64(line.Item.Quantity)), "uncapped"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFloorAtZeroStep(t *testing.T) {
+	tests := []struct {
+		name           string
+		item           CartItem
+		wantTotal      int64
+		wantAdjustment int64
+		wantNote       string
+	}{
+		{name: "discount larger than the line", item: NewCartItem(uuid.New(), "clearance", usd(500), 2, usd(700)), wantTotal: 0, wantAdjustment: 400, wantNote: "price floored at zero"},
+		{name: "discount equal to the line", item: NewCartItem(uuid.New(), "free", usd(500), 2, usd(500)), wantTotal: 0, wantNote: "not below zero"},
+		{name: "discount smaller than the line", item: NewCartItem(uuid.New(), "mug", usd(800), 1, usd(100)), wantTotal: 700, wantNote: "not below zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing, err := NewPricingPipeline(BasePriceStep, uncappedDiscountStep, FloorAtZeroStep).Price([]CartItem{tt.item})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			line := pricing.Lines[0]
+			if !line.Total.Equals(usd(tt.wantTotal)) || !pricing.Total.Equals(usd(tt.wantTotal)) {
+				t.Errorf("got line total %s and total %s, want %s", line.Total, pricing.Total, usd(tt.wantTotal))
+			}
+			if !line.Adjustment.Equals(usd(tt.wantAdjustment)) || !pricing.Adjustment.Equals(usd(tt.wantAdjustment)) {
+				t.Errorf("got line adjustment %s and adjustment %s, want %s", line.Adjustment, pricing.Adjustment, usd(tt.wantAdjustment))
+			}
+
+			floor := line.Explanation[len(line.Explanation)-1]
+			if floor.Step != PricingFloor || !floor.Amount.Equals(usd(tt.wantAdjustment)) || floor.Description != tt.wantNote {
+				t.Errorf("got floor entry %+v, want %s of %s", floor, tt.wantNote, usd(tt.wantAdjustment))
+			}
+		})
+	}
+}
+
+func TestRoundingStep(t *testing.T) {
+	tests := []struct {
+		name       string
+		increment  int64
+		price      int64
+		wantTotal  int64
+		wantAmount int64
+	}{
+		{name: "whole minor units", increment: 1, price: 1999, wantTotal: 1999},
+		{name: "rounds up to the increment", increment: 5, price: 1998, wantTotal: 2000, wantAmount: 2},
+		{name: "rounds down to the increment", increment: 5, price: 1996, wantTotal: 1995, wantAmount: -1},
+		{name: "half rounds away from zero", increment: 10, price: 1995, wantTotal: 2000, wantAmount: 5},
+		{name: "already a multiple", increment: 5, price: 1995, wantTotal: 1995},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := NewCartItem(uuid.New(), "book", usd(tt.price), 1, usd(0))
+			pricing, err := NewPricingPipeline(BasePriceStep, RoundingStep(tt.increment)).Price([]CartItem{item})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			line := pricing.Lines[0]
+			if !line.Total.Equals(usd(tt.wantTotal)) {
+				t.Errorf("got total %s, want %s", line.Total, usd(tt.wantTotal))
+			}
+
+			rounding := line.Explanation[len(line.Explanation)-1]
+			if rounding.Step != PricingRounding || !rounding.Amount.Equals(usd(tt.wantAmount)) {
+				t.Errorf("got rounding entry %+v, want %s", rounding, usd(tt.wantAmount))
+			}
+		})
+	}
+}
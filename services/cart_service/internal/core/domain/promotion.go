@@ -0,0 +1,294 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+type PromotionType string
+
+const (
+	PercentageOff  PromotionType = "PERCENTAGE_OFF"
+	FixedAmountOff PromotionType = "FIXED_AMOUNT_OFF"
+	BuyXGetY       PromotionType = "BUY_X_GET_Y"
+)
+
+// Promotion is a coupon that can be applied to a cart.
+// ProductID restricts it to a single product, MinSubtotal sets the threshold the cart must reach
+// and UsageLimitPerUser caps how many checkouts a user can redeem it on (0 means unlimited).
+type Promotion struct {
+	ID                uuid.UUID
+	Code              string
+	Description       string
+	Type              PromotionType
+	Percentage        float64
+	Amount            money.Money
+	BuyQuantity       int
+	FreeQuantity      int
+	ProductID         *uuid.UUID
+	MinSubtotal       money.Money
+	StartsAt          time.Time
+	ExpiresAt         time.Time
+	UsageLimitPerUser int
+	IsActive          bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// PromotionDiscount is the part of a promotion applied to one cart line.
+type PromotionDiscount struct {
+	PromotionID uuid.UUID
+	Code        string
+	ItemID      uuid.UUID
+	ProductID   uuid.UUID
+	Amount      money.Money
+}
+
+type PromotionRedemption struct {
+	ID          uuid.UUID
+	PromotionID uuid.UUID
+	UserID      uuid.UUID
+	CheckoutID  uuid.UUID
+	RedeemedAt  time.Time
+}
+
+func NewPromotion(promotion Promotion) (*Promotion, error) {
+	promotion.ID = uuid.New()
+	promotion.CreatedAt = time.Now()
+	promotion.UpdatedAt = time.Now()
+	promotion.Code = strings.ToUpper(strings.TrimSpace(promotion.Code))
+
+	if err := promotion.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func NewPromotionRedemption(promotionID, userID, checkoutID uuid.UUID) PromotionRedemption {
+	return PromotionRedemption{
+		ID:          uuid.New(),
+		PromotionID: promotionID,
+		UserID:      userID,
+		CheckoutID:  checkoutID,
+		RedeemedAt:  time.Now(),
+	}
+}
+
+func (p *Promotion) Validate() error {
+	if p.Code == "" {
+		return ErrPromotionInvalid.Withf("promotion: code is required")
+	}
+
+	if !p.ExpiresAt.After(p.StartsAt) {
+		return ErrPromotionInvalid.Withf("promotion: expiry date must be after start date")
+	}
+
+	if p.UsageLimitPerUser < 0 {
+		return ErrPromotionInvalid.Withf("promotion: usage limit cannot be negative")
+	}
+
+	if p.MinSubtotal.IsNegative() {
+		return ErrPromotionInvalid.Withf("promotion: minimum subtotal cannot be negative")
+	}
+
+	switch p.Type {
+	case PercentageOff:
+		if p.Percentage <= 0 || p.Percentage > 100 {
+			return ErrPromotionInvalid.Withf("promotion: percentage must be between 0 and 100")
+		}
+	case FixedAmountOff:
+		if !p.Amount.IsPositive() {
+			return ErrPromotionInvalid.Withf("promotion: amount must be greater than zero")
+		}
+	case BuyXGetY:
+		if p.BuyQuantity <= 0 || p.FreeQuantity <= 0 {
+			return ErrPromotionInvalid.Withf("promotion: buy and free quantities must be greater than zero")
+		}
+	default:
+		return ErrPromotionInvalid.Withf("promotion: unknown promotion type")
+	}
+
+	return nil
+}
+
+func (p *Promotion) Update(changes Promotion) error {
+	p.Description = changes.Description
+	p.Type = changes.Type
+	p.Percentage = changes.Percentage
+	p.Amount = changes.Amount
+	p.BuyQuantity = changes.BuyQuantity
+	p.FreeQuantity = changes.FreeQuantity
+	p.ProductID = changes.ProductID
+	p.MinSubtotal = changes.MinSubtotal
+	p.StartsAt = changes.StartsAt
+	p.ExpiresAt = changes.ExpiresAt
+	p.UsageLimitPerUser = changes.UsageLimitPerUser
+	p.IsActive = changes.IsActive
+	p.UpdatedAt = time.Now()
+
+	return p.Validate()
+}
+
+func (p *Promotion) IsAvailableAt(now time.Time) bool {
+	return p.IsActive && !now.Before(p.StartsAt) && now.Before(p.ExpiresAt)
+}
+
+// ValidateRedemption checks whether a user that already redeemed the promotion timesUsed times can use it now.
+func (p *Promotion) ValidateRedemption(timesUsed int, now time.Time) error {
+	if !p.IsAvailableAt(now) {
+		return ErrCouponUnavailable
+	}
+
+	if p.UsageLimitPerUser > 0 && timesUsed >= p.UsageLimitPerUser {
+		return ErrCouponUsageLimit
+	}
+
+	return nil
+}
+
+func (p *Promotion) SupportsCurrency(currency string) bool {
+	return p.Amount.SameCurrency(money.Zero(currency)) && p.MinSubtotal.SameCurrency(money.Zero(currency))
+}
+
+// MeetsMinimum fails with ErrCurrencyMismatch when the items are not all in the currency of the promotion.
+func (p *Promotion) MeetsMinimum(items []CartItem) (bool, error) {
+	if p.MinSubtotal.IsZero() {
+		return true, nil
+	}
+
+	_, subTotal, err := lineTotals(items)
+	if err != nil {
+		return false, err
+	}
+
+	cmp, err := subTotal.Compare(p.MinSubtotal)
+	if err != nil {
+		return false, ErrCurrencyMismatch.Withf("cart: coupon %s is not valid for %s carts", p.Code, subTotal.Currency())
+	}
+	return cmp >= 0, nil
+}
+
+// CalculateDiscounts returns the discount the promotion gives to each eligible line.
+// A line is never discounted below zero.
+func (p *Promotion) CalculateDiscounts(items []CartItem) ([]PromotionDiscount, error) {
+	if len(items) == 0 || !p.SupportsCurrency(items[0].UnitPrice.Currency()) {
+		return nil, nil
+	}
+
+	meetsMinimum, err := p.MeetsMinimum(items)
+	if err != nil || !meetsMinimum {
+		return nil, err
+	}
+
+	eligible := make([]CartItem, 0, len(items))
+	for _, item := range items {
+		if p.ProductID == nil || *p.ProductID == item.ProductID {
+			eligible = append(eligible, item)
+		}
+	}
+
+	totals, total, err := lineTotals(eligible)
+	if err != nil {
+		return nil, err
+	}
+
+	var amounts []money.Money
+	switch p.Type {
+	case PercentageOff:
+		amounts = p.percentageAmounts(totals)
+	case FixedAmountOff:
+		if amounts, err = p.fixedAmounts(totals, total); err != nil {
+			return nil, err
+		}
+	case BuyXGetY:
+		amounts = p.buyXGetYAmounts(eligible)
+	}
+
+	discounts := make([]PromotionDiscount, 0, len(amounts))
+	for i, amount := range amounts {
+		if amount, err = amount.Min(totals[i]); err != nil {
+			return nil, ErrCurrencyMismatch.Withf("cart: coupon %s is not valid for %s carts", p.Code, totals[i].Currency())
+		}
+		if !amount.IsPositive() {
+			continue
+		}
+
+		discounts = append(discounts, PromotionDiscount{
+			PromotionID: p.ID,
+			Code:        p.Code,
+			ItemID:      eligible[i].ID,
+			ProductID:   eligible[i].ProductID,
+			Amount:      amount,
+		})
+	}
+
+	return discounts, nil
+}
+
+func (p *Promotion) percentageAmounts(totals []money.Money) []money.Money {
+	amounts := make([]money.Money, len(totals))
+	for i, lineTotal := range totals {
+		amounts[i] = lineTotal.Percent(p.Percentage)
+	}
+	return amounts
+}
+
+// fixedAmounts spreads the fixed amount over the lines proportionally to their totals.
+// The last line takes the rounding remainder.
+func (p *Promotion) fixedAmounts(totals []money.Money, total money.Money) ([]money.Money, error) {
+	amounts := make([]money.Money, len(totals))
+	if len(totals) == 0 || !total.IsPositive() {
+		return amounts, nil
+	}
+
+	remaining, err := p.Amount.Min(total)
+	if err != nil {
+		return nil, ErrCurrencyMismatch.Withf("cart: coupon %s is not valid for %s carts", p.Code, total.Currency())
+	}
+
+	toSpread := remaining
+	for i, lineTotal := range totals {
+		if i == len(totals)-1 {
+			amounts[i] = remaining
+			break
+		}
+		share := money.New(toSpread.Amount()*lineTotal.Amount()/total.Amount(), toSpread.Currency())
+		amounts[i] = share
+		if remaining, err = remaining.Sub(share); err != nil {
+			return nil, err
+		}
+	}
+	return amounts, nil
+}
+
+func (p *Promotion) buyXGetYAmounts(items []CartItem) []money.Money {
+	amounts := make([]money.Money, len(items))
+	groupSize := p.BuyQuantity + p.FreeQuantity
+	for i, item := range items {
+		freeUnits := (item.Quantity / groupSize) * p.FreeQuantity
+		amounts[i] = item.UnitPrice.Multiply(int64(freeUnits))
+	}
+	return amounts
+}
+
+// lineTotals returns the total of each line after its catalog discount and their sum.
+// It fails with ErrCurrencyMismatch when the lines are not all in the same currency.
+func lineTotals(items []CartItem) ([]money.Money, money.Money, error) {
+	totals := make([]money.Money, len(items))
+	sum := money.Money{}
+	for i, item := range items {
+		lineTotal, err := item.LineTotal()
+		if err != nil {
+			return nil, money.Money{}, err
+		}
+		if sum, err = sum.Add(lineTotal); err != nil {
+			return nil, money.Money{}, ErrCurrencyMismatch.Withf("cart: %s is not in the currency of the other items", item.Name)
+		}
+		totals[i] = lineTotal
+	}
+	return totals, sum, nil
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The saved for later list sits next to the cart items. Saved items are not bought and
+// do not count towards the items limit. They keep the price they had when saved,
+// the current price is looked up when the list is read or the item moves back.
+
+// MoveToSaved takes a line out of the cart and keeps it in the saved list.
+// A product that is already saved has its quantity increased.
+func (c *Cart) MoveToSaved(itemID uuid.UUID) error {
+	item, err := c.GetItem(itemID)
+	if err != nil {
+		return err
+	}
+	saved := *item
+
+	if err := c.RemoveItem(itemID); err != nil {
+		return err
+	}
+
+	for i, existing := range c.SavedItems {
+		if existing.ProductID == saved.ProductID {
+			c.SavedItems[i].Quantity += saved.Quantity
+			return nil
+		}
+	}
+
+	saved.AddedAt = time.Now()
+	c.SavedItems = append(c.SavedItems, saved)
+
+	return nil
+}
+
+// MoveToCart puts a saved item back in the cart at the current catalog price, following the AddItem rules.
+// maxQuantity is the product limit, 0 means no limit. On error both lists are left unchanged.
+func (c *Cart) MoveToCart(savedItemID uuid.UUID, current CatalogPrice, maxQuantity int) error {
+	index := -1
+	for i, saved := range c.SavedItems {
+		if saved.ID == savedItemID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return ErrSavedItemNotFound
+	}
+
+	if !current.Available {
+		return ErrProductUnavailable.Withf("cart: product is no longer available")
+	}
+
+	item := c.SavedItems[index]
+	item.UnitPrice = current.UnitPrice
+	item.Discount = current.Discount
+	item.MaxQuantity = maxQuantity
+	item.AddedAt = time.Now()
+
+	if err := c.AddItem(item); err != nil {
+		return err
+	}
+
+	c.SavedItems = append(c.SavedItems[:index:index], c.SavedItems[index+1:]...)
+	return nil
+}
+
+func (c *Cart) RemoveSavedItem(savedItemID uuid.UUID) error {
+	for i, saved := range c.SavedItems {
+		if saved.ID == savedItemID {
+			c.SavedItems = append(c.SavedItems[:i:i], c.SavedItems[i+1:]...)
+			c.updateAction()
+			return nil
+		}
+	}
+	return ErrSavedItemNotFound
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// StockItem is the number of units of a product held by a reservation.
+type StockItem struct {
+	ProductID uuid.UUID
+	Quantity  int
+}
+
+// InsufficientStockError names the product that could not be reserved. It matches ErrInsufficientStock.
+type InsufficientStockError struct {
+	ProductID uuid.UUID
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("inventory: not enough stock for product %s", e.ProductID)
+}
+
+func (e *InsufficientStockError) Unwrap() error {
+	return ErrInsufficientStock
+}
+
+// StockItems returns the units to reserve for the checkout, one entry per product.
+func (c *Checkout) StockItems() []StockItem {
+	indexes := make(map[uuid.UUID]int)
+	stockItems := []StockItem{}
+
+	for _, item := range c.Items {
+		if i, found := indexes[item.ProductID]; found {
+			stockItems[i].Quantity += item.Quantity
+			continue
+		}
+
+		indexes[item.ProductID] = len(stockItems)
+		stockItems = append(stockItems, StockItem{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+
+	return stockItems
+}
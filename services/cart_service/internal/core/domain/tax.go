@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+)
+
+// TaxAddress is the part of the buyer's address that decides which taxes apply.
+// Country is an ISO 3166-1 alpha-2 code and State the subdivision code without the country prefix.
+type TaxAddress struct {
+	Country    string
+	State      string
+	PostalCode string
+}
+
+// TaxLine is one tax charged on the cart, e.g. a federal and a state tax are two lines.
+// Rate is a percentage.
+type TaxLine struct {
+	Name   string
+	Rate   float64
+	Amount money.Money
+}
+
+func NewTaxAddress(country, state, postalCode string) TaxAddress {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	state = strings.ToUpper(strings.TrimSpace(state))
+	// Accept full ISO 3166-2 codes such as "US-NY"
+	state = strings.TrimPrefix(state, country+"-")
+
+	return TaxAddress{
+		Country:    country,
+		State:      state,
+		PostalCode: strings.TrimSpace(postalCode),
+	}
+}
+
+// TotalTax fails with ErrCurrencyMismatch when a line is not in currency.
+func TotalTax(lines []TaxLine, currency string) (money.Money, error) {
+	total := money.Zero(currency)
+	for _, line := range lines {
+		sum, err := total.Add(line.Amount)
+		if err != nil {
+			return money.Money{}, ErrCurrencyMismatch.Withf("cart: tax %s is not in %s", line.Name, currency)
+		}
+		total = sum
+	}
+	return total, nil
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserEventType is a user lifecycle event published by user_service.
+type UserEventType string
+
+const (
+	UserActivated UserEventType = "UserActivated"
+	UserDeleted   UserEventType = "UserDeleted"
+	UserBanned    UserEventType = "UserBanned"
+)
+
+// UserEvent is a lifecycle change of a user. ID identifies the event, a redelivery keeps the same ID.
+type UserEvent struct {
+	ID         uuid.UUID
+	Type       UserEventType
+	UserID     uuid.UUID
+	OccurredAt time.Time
+}
+
+func NewUserEvent(id uuid.UUID, eventType UserEventType, userID uuid.UUID, occurredAt time.Time) (*UserEvent, error) {
+	if id == uuid.Nil || userID == uuid.Nil {
+		return nil, ErrInvalidUserEvent.Withf("user events: event_id and user_id are required")
+	}
+
+	switch eventType {
+	case UserActivated, UserDeleted, UserBanned:
+	default:
+		return nil, ErrInvalidUserEvent.Withf("user events: unknown event type %q", eventType)
+	}
+
+	return &UserEvent{
+		ID:         id,
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: occurredAt,
+	}, nil
+}
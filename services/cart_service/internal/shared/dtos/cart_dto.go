@@ -1,12 +1,107 @@
 package dtos
 
 import (
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
 	"github.com/google/uuid"
 )
 
 type CartDTO struct {
-	ID       uuid.UUID     `json:"id"`
-	UserID   uuid.UUID     `json:"user_id"`
-	Items    []CartItemDTO `json:"items"`
-	SubTotal float64       `json:"sub_total"`
+	ID                uuid.UUID              `json:"id"`
+	UserID            uuid.UUID              `json:"user_id"`
+	Items             []CartItemDTO          `json:"items"`
+	CouponCode        string                 `json:"coupon_code,omitempty"`
+	Promotions        []PromotionDiscountDTO `json:"promotions"`
+	SubTotal          money.Money            `json:"sub_total"`
+	PromotionDiscount money.Money            `json:"promotion_discount"`
+	Total             money.Money            `json:"total"`
+	TaxLines          []TaxLineDTO           `json:"tax_lines"`
+	TaxTotal          money.Money            `json:"tax_total"`
+	GrandTotal        money.Money            `json:"grand_total"`
+	Display           *CartDisplayDTO        `json:"display,omitempty"`
+	CheckoutFrozen    bool                   `json:"checkout_frozen,omitempty"`
+	Version           int                    `json:"version"`
+}
+
+// CartDisplayDTO has the cart totals converted to the display currency at Rate, published at RateAsOf.
+type CartDisplayDTO struct {
+	Currency          string      `json:"currency"`
+	Rate              float64     `json:"rate"`
+	RateAsOf          time.Time   `json:"rate_as_of"`
+	SubTotal          money.Money `json:"sub_total"`
+	PromotionDiscount money.Money `json:"promotion_discount"`
+	Total             money.Money `json:"total"`
+	TaxTotal          money.Money `json:"tax_total"`
+	GrandTotal        money.Money `json:"grand_total"`
+}
+
+type DisplayCurrencyDTO struct {
+	Currency string `json:"currency"`
+}
+
+type TaxLineDTO struct {
+	Name   string      `json:"name"`
+	Rate   float64     `json:"rate"`
+	Amount money.Money `json:"amount"`
+}
+
+// GuestCartDTO is returned when a guest cart is created. The token must be sent back in the X-Cart-Token header.
+type GuestCartDTO struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Cart      CartDTO   `json:"cart"`
+}
+
+type ItemChangeDTO struct {
+	ItemID       uuid.UUID   `json:"item_id"`
+	ProductID    uuid.UUID   `json:"product_id"`
+	Name         string      `json:"name"`
+	Reason       string      `json:"reason"`
+	OldUnitPrice money.Money `json:"old_unit_price"`
+	NewUnitPrice money.Money `json:"new_unit_price"`
+	OldDiscount  money.Money `json:"old_discount"`
+	NewDiscount  money.Money `json:"new_discount"`
+}
+
+// CartValidationDTO tells whether the cart can be bought at the prices it shows.
+type CartValidationDTO struct {
+	CartID  uuid.UUID       `json:"cart_id"`
+	Valid   bool            `json:"valid"`
+	Changes []ItemChangeDTO `json:"changes"`
+}
+
+// CartEventDTO is one entry of a cart timeline. Quantity is the quantity of the line after the event.
+type CartEventDTO struct {
+	Type             string      `json:"type"`
+	ItemID           uuid.UUID   `json:"item_id"`
+	ProductID        uuid.UUID   `json:"product_id"`
+	Name             string      `json:"name"`
+	UnitPrice        money.Money `json:"unit_price"`
+	Discount         money.Money `json:"discount"`
+	Quantity         int         `json:"quantity"`
+	PreviousQuantity int         `json:"previous_quantity"`
+	CheckoutID       *uuid.UUID  `json:"checkout_id,omitempty"`
+	OccurredAt       time.Time   `json:"occurred_at"`
+}
+
+// CartTimelineDTO lists the events of a cart, oldest first.
+type CartTimelineDTO struct {
+	CartID uuid.UUID      `json:"cart_id"`
+	UserID uuid.UUID      `json:"user_id"`
+	Events []CartEventDTO `json:"events"`
+}
+
+// CartShareDTO is returned once when a cart is shared, the token cannot be read again.
+type CartShareDTO struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CartSnapshotDTO is the read-only view of a shared cart, with the prices it had when it was shared.
+type CartSnapshotDTO struct {
+	Items     []CartItemDTO `json:"items"`
+	SubTotal  money.Money   `json:"sub_total"`
+	CreatedAt time.Time     `json:"created_at"`
+	ExpiresAt time.Time     `json:"expires_at"`
 }
@@ -1,7 +1,10 @@
 package dtos
 
 import (
+	"time"
+
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
 	"github.com/google/uuid"
 )
 
@@ -10,17 +13,46 @@ type CartItemInserDTO struct {
 	Quantity  int       `json:"quantity"`
 }
 
+type CartItemQuantityDTO struct {
+	Quantity int `json:"quantity"`
+}
+
 type CartItemFetchedDTO struct {
 	ProductData facadeService.Product `json:"product_data"`
 	Quantity    int
 }
 
+// CartItemDTO is a cart line. Discount is per unit, LineTotal is the price of the line with its discounts and
+// Pricing explains how it was derived, step by step.
 type CartItemDTO struct {
-	ID        uuid.UUID `json:"id"`
-	CartID    uuid.UUID `json:"cart_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Name      string    `json:"name"`
-	UnitPrice float64   `json:"unit_price"`
-	Quantity  int       `json:"quantity"`
-	Discount  float64   `json:"discount"`
+	ID          uuid.UUID            `json:"id"`
+	CartID      uuid.UUID            `json:"cart_id"`
+	ProductID   uuid.UUID            `json:"product_id"`
+	ProductType string               `json:"product_type"`
+	Name        string               `json:"name"`
+	UnitPrice   money.Money          `json:"unit_price"`
+	Quantity    int                  `json:"quantity"`
+	Discount    money.Money          `json:"discount"`
+	LineTotal   money.Money          `json:"line_total"`
+	Pricing     []PriceAdjustmentDTO `json:"pricing,omitempty"`
+}
+
+// PriceAdjustmentDTO is one step of the pricing of a line, Amount is negative for discounts.
+type PriceAdjustmentDTO struct {
+	Step        string      `json:"step"`
+	Amount      money.Money `json:"amount"`
+	Description string      `json:"description"`
+}
+
+// SavedItemDTO is a saved for later line with the price it was saved at and its current catalog price.
+type SavedItemDTO struct {
+	ID              uuid.UUID   `json:"id"`
+	ProductID       uuid.UUID   `json:"product_id"`
+	Name            string      `json:"name"`
+	Quantity        int         `json:"quantity"`
+	SavedPrice      money.Money `json:"saved_price"`
+	CurrentPrice    money.Money `json:"current_price"`
+	CurrentDiscount money.Money `json:"current_discount"`
+	IsAvailable     bool        `json:"is_available"`
+	SavedAt         time.Time   `json:"saved_at"`
 }
@@ -0,0 +1,62 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// MaxCartOperations is the most operations a single bulk request may carry.
+const MaxCartOperations = 100
+
+// Operations accepted by the bulk cart endpoint.
+const (
+	CartOperationAdd         = "add"
+	CartOperationRemove      = "remove"
+	CartOperationSetQuantity = "set_quantity"
+)
+
+// Status of each operation of a bulk request.
+const (
+	CartOperationApplied = "applied"
+	CartOperationFailed  = "failed"
+	CartOperationSkipped = "skipped"
+)
+
+// Machine readable reasons an operation was not applied. An operation breaking a cart rule
+// carries the Code of its domain.DomainError instead, e.g. "QUANTITY_LIMIT_EXCEEDED".
+const (
+	CartOperationErrInvalidOperation = "INVALID_OPERATION"
+	CartOperationErrProductNotFound  = "PRODUCT_NOT_FOUND"
+	CartOperationErrAborted          = "ABORTED"
+	CartOperationErrUnknown          = "UNKNOWN"
+)
+
+// CartOperationDTO is one change of a bulk request.
+// add uses ProductID and Quantity, remove uses ItemID, set_quantity uses ItemID and Quantity.
+type CartOperationDTO struct {
+	Op        string    `json:"op"`
+	ProductID uuid.UUID `json:"product_id,omitempty"`
+	ItemID    uuid.UUID `json:"item_id,omitempty"`
+	Quantity  int       `json:"quantity,omitempty"`
+}
+
+// CartOperationsDTO is a list of changes applied in order.
+// With AllOrNothing a single failing operation leaves the cart unchanged, otherwise the valid ones are applied.
+type CartOperationsDTO struct {
+	Operations   []CartOperationDTO `json:"operations"`
+	AllOrNothing bool               `json:"all_or_nothing"`
+}
+
+// CartOperationResultDTO is the outcome of the operation at Index of the request.
+type CartOperationResultDTO struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	Status  string `json:"status"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// CartOperationsResultDTO has one result per operation, in request order, and the cart once the changes are saved.
+type CartOperationsResultDTO struct {
+	Applied int                      `json:"applied"`
+	Failed  int                      `json:"failed"`
+	Results []CartOperationResultDTO `json:"results"`
+	Cart    *CartDTO                 `json:"cart"`
+}
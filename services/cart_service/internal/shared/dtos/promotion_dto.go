@@ -0,0 +1,54 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+type PromotionInsertDTO struct {
+	Code              string      `json:"code"`
+	Description       string      `json:"description"`
+	Type              string      `json:"type"`
+	Percentage        float64     `json:"percentage"`
+	Amount            money.Money `json:"amount"`
+	BuyQuantity       int         `json:"buy_quantity"`
+	FreeQuantity      int         `json:"free_quantity"`
+	ProductID         *uuid.UUID  `json:"product_id"`
+	MinSubtotal       money.Money `json:"min_subtotal"`
+	StartsAt          time.Time   `json:"starts_at"`
+	ExpiresAt         time.Time   `json:"expires_at"`
+	UsageLimitPerUser int         `json:"usage_limit_per_user"`
+	IsActive          bool        `json:"is_active"`
+}
+
+type PromotionDTO struct {
+	ID                uuid.UUID   `json:"id"`
+	Code              string      `json:"code"`
+	Description       string      `json:"description"`
+	Type              string      `json:"type"`
+	Percentage        float64     `json:"percentage,omitempty"`
+	Amount            money.Money `json:"amount"`
+	BuyQuantity       int         `json:"buy_quantity,omitempty"`
+	FreeQuantity      int         `json:"free_quantity,omitempty"`
+	ProductID         *uuid.UUID  `json:"product_id,omitempty"`
+	MinSubtotal       money.Money `json:"min_subtotal"`
+	StartsAt          time.Time   `json:"starts_at"`
+	ExpiresAt         time.Time   `json:"expires_at"`
+	UsageLimitPerUser int         `json:"usage_limit_per_user"`
+	IsActive          bool        `json:"is_active"`
+}
+
+// PromotionDiscountDTO tells which promotion discounted which cart line and by how much.
+type PromotionDiscountDTO struct {
+	PromotionID uuid.UUID   `json:"promotion_id"`
+	Code        string      `json:"code"`
+	ItemID      uuid.UUID   `json:"item_id"`
+	ProductID   uuid.UUID   `json:"product_id"`
+	Amount      money.Money `json:"amount"`
+}
+
+type ApplyCouponDTO struct {
+	Code string `json:"code"`
+}
@@ -3,27 +3,66 @@ package dtos
 import (
 	"time"
 
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
 	"github.com/google/uuid"
 )
 
 type PurchaseDetails struct {
-	UserID         uuid.UUID
-	TotalAmount    float64
-	TotalDisscount float64
-	PurchaseDate   time.Time
-	Items          []ItemDTO
+	CheckoutID     uuid.UUID   `json:"checkout_id"`
+	CartID         uuid.UUID   `json:"cart_id"`
+	UserID         uuid.UUID   `json:"user_id"`
+	SubTotal       money.Money `json:"sub_total"`
+	TotalAmount    money.Money `json:"total_amount"`
+	TotalDisscount money.Money `json:"total_discount"`
+	CouponCode     string      `json:"coupon_code,omitempty"`
+	PromotionTotal money.Money `json:"promotion_discount"`
+	PurchaseDate   time.Time   `json:"purchase_date"`
+	Items          []ItemDTO   `json:"items"`
+	// The display fields are set when the buyer saw the cart in another currency, with the rate locked at checkout
+	DisplayCurrency  string       `json:"display_currency,omitempty"`
+	ExchangeRate     float64      `json:"exchange_rate,omitempty"`
+	ExchangeRateAsOf *time.Time   `json:"exchange_rate_as_of,omitempty"`
+	DisplayTotal     *money.Money `json:"display_total,omitempty"`
 }
 
 type ItemDTO struct {
-	Name      string  `json:"name"`
-	UnitPrice float64 `json:"unit_price"`
-	Quantity  int     `json:"quantity"`
-	Discount  float64 `json:"discount"`
+	ProductID   uuid.UUID   `json:"product_id"`
+	ProductType string      `json:"product_type"`
+	Name        string      `json:"name"`
+	UnitPrice   money.Money `json:"unit_price"`
+	Quantity    int         `json:"quantity"`
+	Discount    money.Money `json:"discount"`
 }
 
 type OrderDetails struct {
-	UserID    uuid.UUID
-	ItemCount uint
-	CreatedAt time.Time
-	Items     []ItemDTO
+	CheckoutID uuid.UUID `json:"checkout_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	ItemCount  uint      `json:"item_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	Items      []ItemDTO `json:"items"`
+}
+
+// CheckoutEvent is the message sent downstream once a cart has been bought.
+type CheckoutEvent struct {
+	Purchase PurchaseDetails `json:"purchase"`
+	Order    OrderDetails    `json:"order"`
+}
+
+// CartAbandonedEvent is sent when a cart holding items stays idle, so the user can be reminded of it.
+type CartAbandonedEvent struct {
+	CartID         uuid.UUID   `json:"cart_id"`
+	UserID         uuid.UUID   `json:"user_id"`
+	Items          []ItemDTO   `json:"items"`
+	SubTotal       money.Money `json:"sub_total"`
+	LastActivityAt time.Time   `json:"last_activity_at"`
+	DetectedAt     time.Time   `json:"detected_at"`
+}
+
+// CoursePurchaseEvent is sent when a checkout holding courses is committed, so course_service enrolls the buyer.
+// Consumers must deduplicate by checkout id and course id.
+type CoursePurchaseEvent struct {
+	CheckoutID  uuid.UUID   `json:"checkout_id"`
+	UserID      uuid.UUID   `json:"user_id"`
+	CourseIDs   []uuid.UUID `json:"course_ids"`
+	PurchasedAt time.Time   `json:"purchased_at"`
 }
@@ -0,0 +1,16 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserEventDTO is a user lifecycle event as published by user_service on the user events queue.
+// EventType is one of UserActivated, UserDeleted or UserBanned.
+type UserEventDTO struct {
+	EventID    uuid.UUID `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	UserID     uuid.UUID `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -1,23 +1,29 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"time"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/config"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/input/consumer"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/input/v1/http/api/handlers"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/input/v1/http/api/routes"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/inventory"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/publisher"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/repository"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/adapters/output/tax"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/internal/core/application/usecases"
 	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/facadeService"
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/rabbitmq"
 	ratelimiter "github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/rate_limiter"
 	"github.com/gofiber/fiber/v2"
 )
 
 func main() {
 	// ROUTER
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
 
 	// db
 	gormDB := config.GORMConfig()
@@ -33,19 +39,84 @@ func main() {
 	// APP
 	// repository
 	itemRepository := repository.NewCartItemRepository(gormDB)
-	cartRepository := repository.NewCartRepository(gormDB, *itemRepository)
+	cartEventRepository := repository.NewCartEventRepository(gormDB)
+	cartSnapshotRepository := repository.NewCartSnapshotRepository(gormDB)
+	cartRepository := repository.NewCartRepository(gormDB, *itemRepository, cartEventRepository)
+	var cartCache *repository.CachedCartRepository
+	if cartCacheConfig := config.GetCartCacheConfig(); cartCacheConfig.Enabled {
+		cartCache = repository.NewCachedCartRepository(cartRepository, config.RedisClient, cartCacheConfig.TTL)
+		cartRepository = cartCache
+	}
+	checkoutRepository := repository.NewCheckoutRepository(gormDB)
+	outboxRepository := repository.NewOutboxRepository(gormDB)
+	promotionRepository := repository.NewPromotionRepository(gormDB)
+	transactionManager := repository.NewTransactionManager(gormDB)
+	guestCartRepository := repository.NewGuestCartRepository(config.RedisClient)
+	inventoryReservation := inventory.NewRedisInventoryReservation(config.RedisClient)
+
+	// rabbitmq
+	rabbitConn, err := rabbitmq.ConnectRabbitMQ()
+	if err != nil {
+		log.Fatal("Failed to connect to RabbitMQ:", err)
+	}
+	defer rabbitConn.Close()
+	orderPublisher := publisher.NewRabbitMQOrderPublisher(rabbitConn)
+	cartEventPublisher := publisher.NewRabbitMQCartEventPublisher(rabbitConn)
+	coursePurchasePublisher := publisher.NewRabbitMQCoursePurchasePublisher(rabbitConn)
+
+	// outbox relay
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	outboxRelay := usecases.NewOutboxRelay(outboxRepository, orderPublisher, cartEventPublisher, coursePurchasePublisher, 2*time.Second, 50, config.GetOutboxMaxAttempts())
+	go outboxRelay.Start(relayCtx)
+
+	// expired inventory holds
+	reservationReaper := usecases.NewReservationReaper(inventoryReservation, 30*time.Second)
+	go reservationReaper.Start(relayCtx)
+
+	// abandoned carts
+	abandonedCartConfig := config.GetAbandonedCartConfig()
+	abandonedCartUseCase := usecases.NewAbandonedCartUseCase(cartRepository, outboxRepository, transactionManager, abandonedCartConfig.IdleFor, abandonedCartConfig.BatchSize)
+	abandonedCartWorker := usecases.NewAbandonedCartWorker(abandonedCartUseCase, abandonedCartConfig.CheckInterval)
+	go abandonedCartWorker.Start(relayCtx)
+
+	// user lifecycle events
+	processedEventRepository := repository.NewProcessedEventRepository(gormDB)
+	userEventUseCase := usecases.NewUserEventUseCase(cartRepository, cartEventRepository, cartSnapshotRepository, processedEventRepository, transactionManager)
+	userEventsConfig := config.GetUserEventsConfig()
+	userEventConsumer := consumer.NewUserEventConsumer(rabbitConn, userEventUseCase, userEventsConfig.MaxAttempts, userEventsConfig.RetryBackoff)
+	go userEventConsumer.Start(relayCtx)
 
 	// usecases
-	productService := facadeService.NewProductFacadeService()
-	cartUseCase := usecases.NewCartUseCase(cartRepository, productService)
+	productService := facadeService.NewProductFacadeService(config.GetProductServiceConfig())
+	userService := facadeService.NewUserFacadeService(config.GetUserServiceConfig())
+	courseService := facadeService.NewCourseFacadeService(config.GetCourseServiceConfig())
+	taxCalculator := tax.NewRuleTableTaxCalculator(tax.DefaultTaxRules())
+	cartUseCase := usecases.NewCartUseCase(cartRepository, checkoutRepository, outboxRepository, promotionRepository, transactionManager, productService, userService, courseService, taxCalculator, inventoryReservation, config.GetInventoryHoldTTL(), config.GetExchangeRateProvider())
+	promotionUseCase := usecases.NewPromotionUseCase(promotionRepository)
+	cartHistoryUseCase := usecases.NewCartHistoryUseCase(cartEventRepository)
+	cartShareUseCase := usecases.NewCartShareUseCase(cartSnapshotRepository, cartRepository, cartUseCase, config.GetCartShareTTL())
+	guestCartUseCase := usecases.NewGuestCartUseCase(guestCartRepository, cartRepository, cartUseCase, productService, courseService, config.GetGuestCartSigner())
 	cartHandler := handlers.NewCartHandler(cartUseCase)
 
 	// handlers
 	userCartHandler := handlers.NewUserCartHandler(cartUseCase)
+	promotionHandler := handlers.NewPromotionHandler(promotionUseCase)
+	cartHistoryHandler := handlers.NewCartHistoryHandler(cartHistoryUseCase)
+	cartShareHandler := handlers.NewCartShareHandler(cartShareUseCase, cartUseCase)
+	guestCartHandler := handlers.NewGuestCartHandler(guestCartUseCase)
+	abandonedCartHandler := handlers.NewAbandonedCartHandler(abandonedCartUseCase)
+	cartCacheHandler := handlers.NewCartCacheHandler(cartCache)
 
 	// routes
-	routes.CartRoutes(app, *cartHandler)
-	routes.UserCartRoutes(app, *userCartHandler)
+	authenticator := config.GetAuthenticator()
+	routes.CartRoutes(app, *cartHandler, authenticator)
+	routes.UserCartRoutes(app, *userCartHandler, authenticator)
+	routes.CartHistoryRoutes(app, *cartHistoryHandler, authenticator)
+	routes.PromotionRoutes(app, *promotionHandler, authenticator)
+	routes.GuestCartRoutes(app, *guestCartHandler, authenticator)
+	routes.CartShareRoutes(app, *cartShareHandler, authenticator)
+	routes.AdminCartRoutes(app, *abandonedCartHandler, *cartCacheHandler, authenticator)
 
 	app.Get("/home", func(c *fiber.Ctx) error {
 		return c.SendString("Welcome to Cart Service")
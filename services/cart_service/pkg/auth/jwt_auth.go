@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	AdminRole       = "admin"
+	accessTokenType = "ACCESS_TOKEN"
+	claimsKey       = "claims"
+)
+
+var (
+	ErrMissingToken = errors.New("authorization header is required")
+	ErrInvalidToken = errors.New("access token is invalid")
+	ErrExpiredToken = errors.New("access token has expired")
+	ErrForbidden    = errors.New("you are not allowed to access this resource")
+)
+
+// Claims mirrors the claims user_service signs into its access tokens.
+type Claims struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	TokenType string    `json:"token_type"`
+	ExpiresAt time.Time `json:"expires_at"`
+	jwt.RegisteredClaims
+}
+
+// Authenticator validates the HS256 access tokens issued by user_service.
+// It has to share JWT_SECRET_KEY with it.
+type Authenticator struct {
+	secret []byte
+}
+
+func NewAuthenticator(secret []byte) *Authenticator {
+	return &Authenticator{secret: secret}
+}
+
+func (a *Authenticator) Verify(tokenString string, now time.Time) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return a.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithTimeFunc(func() time.Time { return now }))
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	if !token.Valid || claims.TokenType != accessTokenType || claims.UserID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if !claims.ExpiresAt.IsZero() && !now.Before(claims.ExpiresAt) {
+		return nil, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+// Authenticate requires a valid "Authorization: Bearer <token>" header and stores the claims in the request locals.
+// Its errors are answered by the app error handler.
+func (a *Authenticator) Authenticate(c *fiber.Ctx) error {
+	tokenString, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !found || tokenString == "" {
+		return ErrMissingToken
+	}
+
+	claims, err := a.Verify(tokenString, time.Now())
+	if err != nil {
+		return err
+	}
+
+	c.Locals(claimsKey, claims)
+	return c.Next()
+}
+
+// RequireRole must run after Authenticate.
+func RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetClaims(c)
+		if !ok {
+			return ErrMissingToken
+		}
+
+		if !strings.EqualFold(claims.Role, role) {
+			return ErrForbidden
+		}
+
+		return c.Next()
+	}
+}
+
+func GetClaims(c *fiber.Ctx) (*Claims, bool) {
+	claims, ok := c.Locals(claimsKey).(*Claims)
+	return claims, ok
+}
+
+// UserID returns the id of the authenticated caller.
+func UserID(c *fiber.Ctx) (uuid.UUID, error) {
+	claims, ok := GetClaims(c)
+	if !ok {
+		return uuid.Nil, ErrMissingToken
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+	return userID, nil
+}
@@ -0,0 +1,74 @@
+package carttoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidToken = errors.New("cart token is invalid")
+	ErrExpiredToken = errors.New("cart token has expired")
+)
+
+// Signer issues and verifies the tokens that identify guest carts.
+// A token is "<cart id>.<expiry unix>.<signature>" where the signature is an HMAC-SHA256 of the first two parts.
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewSigner(secret []byte, ttl time.Duration) *Signer {
+	return &Signer{secret: secret, ttl: ttl}
+}
+
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+func (s *Signer) Issue(cartID uuid.UUID, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	payload := cartID.String() + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.sign(payload), expiresAt
+}
+
+// Verify returns the cart id carried by token.
+func (s *Signer) Verify(token string, now time.Time) (uuid.UUID, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(payload))) {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	cartID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	if !now.Before(time.Unix(expiry, 0)) {
+		return uuid.Nil, ErrExpiredToken
+	}
+
+	return cartID, nil
+}
+
+func (s *Signer) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
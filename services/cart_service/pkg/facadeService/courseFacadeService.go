@@ -0,0 +1,37 @@
+package facadeService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Course is the course_service view of a product sold as a course.
+type Course struct {
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	IsFree bool      `json:"is_free"`
+}
+
+type CourseFacadeService interface {
+	GetCourseById(ctx context.Context, id uuid.UUID) (*Course, error)
+	IsEnrolled(ctx context.Context, courseID uuid.UUID, userID uuid.UUID) (bool, error)
+}
+
+var ErrCourseNotFound = errors.New("course not found")
+
+// CourseNotFoundError is returned when course_service has no course for the requested id.
+// It matches ErrCourseNotFound through errors.Is.
+type CourseNotFoundError struct {
+	CourseID uuid.UUID
+}
+
+func (e *CourseNotFoundError) Error() string {
+	return fmt.Sprintf("course not found: %s", e.CourseID)
+}
+
+func (e *CourseNotFoundError) Is(target error) bool {
+	return target == ErrCourseNotFound
+}
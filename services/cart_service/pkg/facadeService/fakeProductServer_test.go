@@ -0,0 +1,119 @@
+package facadeService
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FakeProductServer is an in-process catalog that speaks the same HTTP contract as
+// ProductFacadeServiceImpl, the client tests run against it instead of a running product service.
+type FakeProductServer struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	products map[uuid.UUID]Product
+	failures int
+}
+
+func NewFakeProductServer(products ...Product) *FakeProductServer {
+	f := &FakeProductServer{products: make(map[uuid.UUID]Product)}
+	for _, product := range products {
+		f.products[product.Id] = product
+	}
+
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+func (f *FakeProductServer) URL() string {
+	return f.server.URL
+}
+
+func (f *FakeProductServer) Close() {
+	f.server.Close()
+}
+
+func (f *FakeProductServer) SetProduct(product Product) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.products[product.Id] = product
+}
+
+func (f *FakeProductServer) RemoveProduct(id uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.products, id)
+}
+
+// FailNext makes the next n requests answer 503 so retries can be exercised.
+func (f *FakeProductServer) FailNext(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = n
+}
+
+func (f *FakeProductServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failures > 0 {
+		f.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case path == "/products":
+		f.handleBatch(w, r)
+	case strings.HasPrefix(path, "/products/"):
+		f.handleSingle(w, strings.TrimPrefix(path, "/products/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *FakeProductServer) handleSingle(w http.ResponseWriter, idStr string) {
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	product, ok := f.products[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, product)
+}
+
+func (f *FakeProductServer) handleBatch(w http.ResponseWriter, r *http.Request) {
+	products := []Product{}
+	for _, idStr := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		if product, ok := f.products[id]; ok {
+			products = append(products, product)
+		}
+	}
+
+	writeJSON(w, products)
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
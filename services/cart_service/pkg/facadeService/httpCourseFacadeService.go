@@ -0,0 +1,78 @@
+package facadeService
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CourseServiceConfig struct {
+	BaseURL      string
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+type CourseFacadeServiceImpl struct {
+	client *jsonClient
+	config CourseServiceConfig
+}
+
+func NewCourseFacadeService(config CourseServiceConfig) CourseFacadeService {
+	return &CourseFacadeServiceImpl{
+		client: newJSONClient("course service", config.Timeout, config.MaxRetries, config.RetryBackoff),
+		config: config,
+	}
+}
+
+// course_service wraps every payload in its ApiResponse envelope.
+type courseResponse struct {
+	Data *Course `json:"data"`
+}
+
+type enrollmentResponse struct {
+	Data *struct {
+		Enrolled bool `json:"enrolled"`
+	} `json:"data"`
+}
+
+// GET {BaseURL}/courses/{id}
+func (c *CourseFacadeServiceImpl) GetCourseById(ctx context.Context, id uuid.UUID) (*Course, error) {
+	endpoint := fmt.Sprintf("%s/courses/%s", c.baseURL(), id)
+
+	var response courseResponse
+	found, err := c.client.getJSON(ctx, endpoint, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found || response.Data == nil {
+		return nil, &CourseNotFoundError{CourseID: id}
+	}
+
+	return response.Data, nil
+}
+
+// GET {BaseURL}/courses/{id}/enrollments/{userID}
+func (c *CourseFacadeServiceImpl) IsEnrolled(ctx context.Context, courseID uuid.UUID, userID uuid.UUID) (bool, error) {
+	endpoint := fmt.Sprintf("%s/courses/%s/enrollments/%s", c.baseURL(), courseID, userID)
+
+	var response enrollmentResponse
+	found, err := c.client.getJSON(ctx, endpoint, &response)
+	if err != nil {
+		return false, err
+	}
+
+	if !found || response.Data == nil {
+		return false, &CourseNotFoundError{CourseID: courseID}
+	}
+
+	return response.Data.Enrolled, nil
+}
+
+func (c *CourseFacadeServiceImpl) baseURL() string {
+	return strings.TrimRight(c.config.BaseURL, "/")
+}
@@ -0,0 +1,74 @@
+package facadeService
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductServiceConfig struct {
+	BaseURL      string
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+type ProductFacadeServiceImpl struct {
+	client *jsonClient
+	config ProductServiceConfig
+}
+
+func NewProductFacadeService(config ProductServiceConfig) ProductFacadeService {
+	return &ProductFacadeServiceImpl{
+		client: newJSONClient("product service", config.Timeout, config.MaxRetries, config.RetryBackoff),
+		config: config,
+	}
+}
+
+// GET {BaseURL}/products/{id}
+func (p *ProductFacadeServiceImpl) GetProductById(ctx context.Context, id uuid.UUID) (*Product, error) {
+	endpoint := fmt.Sprintf("%s/products/%s", p.baseURL(), id)
+
+	var product Product
+	found, err := p.client.getJSON(ctx, endpoint, &product)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, &ProductNotFoundError{ProductID: id}
+	}
+
+	return &product, nil
+}
+
+// GET {BaseURL}/products?ids={id},{id}. Unknown ids are left out of the response.
+func (p *ProductFacadeServiceImpl) GetProductsByIdIn(ctx context.Context, ids []uuid.UUID) (*[]Product, error) {
+	products := []Product{}
+	if len(ids) == 0 {
+		return &products, nil
+	}
+
+	idList := make([]string, len(ids))
+	for i, id := range ids {
+		idList[i] = id.String()
+	}
+
+	query := url.Values{}
+	query.Set("ids", strings.Join(idList, ","))
+	endpoint := fmt.Sprintf("%s/products?%s", p.baseURL(), query.Encode())
+
+	if _, err := p.client.getJSON(ctx, endpoint, &products); err != nil {
+		return nil, err
+	}
+
+	return &products, nil
+}
+
+func (p *ProductFacadeServiceImpl) baseURL() string {
+	return strings.TrimRight(p.config.BaseURL, "/")
+}
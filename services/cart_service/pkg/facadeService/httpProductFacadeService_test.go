@@ -0,0 +1,172 @@
+package facadeService
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+func newTestProduct(name string, price int64) Product {
+	return Product{
+		Id:          uuid.New(),
+		Name:        name,
+		Price:       money.New(price, "USD"),
+		IsAvalaible: true,
+		Disccount:   money.Zero("USD"),
+	}
+}
+
+func newTestProductService(server *FakeProductServer, maxRetries int) ProductFacadeService {
+	return NewProductFacadeService(ProductServiceConfig{
+		BaseURL:      server.URL() + "/",
+		Timeout:      time.Second,
+		MaxRetries:   maxRetries,
+		RetryBackoff: time.Millisecond,
+	})
+}
+
+func TestGetProductById(t *testing.T) {
+	book := newTestProduct("book", 1999)
+	server := NewFakeProductServer(book)
+	defer server.Close()
+
+	service := newTestProductService(server, 0)
+
+	product, err := service.GetProductById(context.Background(), book.Id)
+	if err != nil {
+		t.Fatalf("GetProductById: %v", err)
+	}
+	if product.Name != book.Name || !product.Price.Equals(book.Price) {
+		t.Errorf("got %+v, want %+v", *product, book)
+	}
+
+	server.RemoveProduct(book.Id)
+	_, err = service.GetProductById(context.Background(), book.Id)
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("removed product: got %v, want ErrProductNotFound", err)
+	}
+
+	var notFound *ProductNotFoundError
+	if !errors.As(err, &notFound) || notFound.ProductID != book.Id {
+		t.Errorf("removed product: got %v, want ProductNotFoundError for %s", err, book.Id)
+	}
+}
+
+func TestGetProductByIdRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		failures   int
+		wantErr    bool
+	}{
+		{name: "no failure", maxRetries: 2, failures: 0},
+		{name: "recovers within the retries", maxRetries: 2, failures: 2},
+		{name: "gives up after the retries", maxRetries: 2, failures: 3, wantErr: true},
+		{name: "no retries", maxRetries: 0, failures: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := newTestProduct("book", 1999)
+			server := NewFakeProductServer(book)
+			defer server.Close()
+
+			server.FailNext(tt.failures)
+			_, err := newTestProductService(server, tt.maxRetries).GetProductById(context.Background(), book.Id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && errors.Is(err, ErrProductNotFound) {
+				t.Errorf("a failing catalog must not be reported as a missing product")
+			}
+			if err != nil && !errors.Is(err, ErrServiceUnavailable) {
+				t.Errorf("got error %v, want ErrServiceUnavailable", err)
+			}
+		})
+	}
+}
+
+func TestGetProductByIdStopsWithContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      func() (context.Context, context.CancelFunc)
+		failures int
+		wantErr  error
+	}{
+		{name: "cancelled before the request", ctx: func() (context.Context, context.CancelFunc) {
+			return cancelled, func() {}
+		}, wantErr: context.Canceled},
+		{name: "deadline during the backoff", ctx: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 50*time.Millisecond)
+		}, failures: 1, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := newTestProduct("book", 1999)
+			server := NewFakeProductServer(book)
+			defer server.Close()
+			server.FailNext(tt.failures)
+
+			service := NewProductFacadeService(ProductServiceConfig{
+				BaseURL:      server.URL(),
+				Timeout:      time.Second,
+				MaxRetries:   2,
+				RetryBackoff: time.Minute,
+			})
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+			_, err := service.GetProductById(ctx, book.Id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrServiceUnavailable) {
+				t.Errorf("a cancelled request must not be reported as an unavailable catalog")
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("returned after %s, want it to stop with the context", elapsed)
+			}
+		})
+	}
+}
+
+func TestGetProductsByIdIn(t *testing.T) {
+	book := newTestProduct("book", 1999)
+	pen := newTestProduct("pen", 250)
+	server := NewFakeProductServer(book)
+	defer server.Close()
+	server.SetProduct(pen)
+
+	service := newTestProductService(server, 0)
+
+	products, err := service.GetProductsByIdIn(context.Background(), []uuid.UUID{book.Id, uuid.New(), pen.Id})
+	if err != nil {
+		t.Fatalf("GetProductsByIdIn: %v", err)
+	}
+	if len(*products) != 2 {
+		t.Fatalf("got %d products, want the 2 known ones", len(*products))
+	}
+
+	found := map[uuid.UUID]bool{}
+	for _, product := range *products {
+		found[product.Id] = true
+	}
+	if !found[book.Id] || !found[pen.Id] {
+		t.Errorf("got %v, want %s and %s", *products, book.Id, pen.Id)
+	}
+
+	products, err = service.GetProductsByIdIn(context.Background(), nil)
+	if err != nil || len(*products) != 0 {
+		t.Errorf("no ids: got %v, %v, want an empty list", products, err)
+	}
+}
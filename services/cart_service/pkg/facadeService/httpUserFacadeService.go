@@ -0,0 +1,58 @@
+package facadeService
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceTokenHeader carries ServiceToken, the credential user_service asks on its internal routes.
+const ServiceTokenHeader = "X-Service-Token"
+
+type UserServiceConfig struct {
+	BaseURL      string
+	ServiceToken string
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+type UserFacadeServiceImpl struct {
+	client *jsonClient
+	config UserServiceConfig
+}
+
+func NewUserFacadeService(config UserServiceConfig) UserFacadeService {
+	client := newJSONClient("user service", config.Timeout, config.MaxRetries, config.RetryBackoff)
+	client.header.Set(ServiceTokenHeader, config.ServiceToken)
+
+	return &UserFacadeServiceImpl{
+		client: client,
+		config: config,
+	}
+}
+
+// user_service wraps every payload in its ApiResponse envelope.
+type addressResponse struct {
+	Data *Address `json:"data"`
+}
+
+// GET {BaseURL}/internal/users/{id}/address/default
+func (u *UserFacadeServiceImpl) GetDefaultAddress(ctx context.Context, userID uuid.UUID) (*Address, error) {
+	endpoint := fmt.Sprintf("%s/internal/users/%s/address/default", strings.TrimRight(u.config.BaseURL, "/"), userID)
+
+	var response addressResponse
+	found, err := u.client.getJSON(ctx, endpoint, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found || response.Data == nil {
+		return nil, ErrAddressNotFound
+	}
+
+	return response.Data, nil
+}
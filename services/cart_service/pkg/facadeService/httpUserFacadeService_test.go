@@ -0,0 +1,73 @@
+package facadeService
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newTestUserServer serves the default address of userID to the callers sending token.
+func newTestUserServer(t *testing.T, userID uuid.UUID, token string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(ServiceTokenHeader) != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/internal/users/"+userID.String()+"/address/default" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(addressResponse{Data: &Address{UserID: userID, Country: "US", State: "NY", IsDefault: true}})
+	}))
+}
+
+func TestGetDefaultAddress(t *testing.T) {
+	userID := uuid.New()
+	server := newTestUserServer(t, userID, "secret")
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		token   string
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{name: "sends the service token", token: "secret", userID: userID},
+		{name: "user without address", token: "secret", userID: uuid.New(), wantErr: ErrAddressNotFound},
+		{name: "wrong service token", token: "guess", userID: userID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewUserFacadeService(UserServiceConfig{
+				BaseURL:      server.URL,
+				ServiceToken: tt.token,
+				Timeout:      time.Second,
+				RetryBackoff: time.Millisecond,
+			})
+
+			address, err := service.GetDefaultAddress(context.Background(), tt.userID)
+			if tt.token != "secret" {
+				if err == nil {
+					t.Fatalf("got address %+v without the service token", address)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (address.UserID != userID || address.Country != "US") {
+				t.Errorf("got address %+v", address)
+			}
+		})
+	}
+}
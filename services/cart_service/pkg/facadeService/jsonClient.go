@@ -0,0 +1,102 @@
+package facadeService
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrServiceUnavailable is returned when a service cannot be reached, or still fails with a 5xx, after the retries.
+// The request may succeed later.
+var ErrServiceUnavailable = errors.New("service unavailable")
+
+// jsonClient is the HTTP client shared by the facades that talk to other services.
+type jsonClient struct {
+	client       *http.Client
+	service      string
+	maxRetries   int
+	retryBackoff time.Duration
+	header       http.Header
+}
+
+func newJSONClient(service string, timeout time.Duration, maxRetries int, retryBackoff time.Duration) *jsonClient {
+	return &jsonClient{
+		client:       &http.Client{Timeout: timeout},
+		service:      service,
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+		header:       http.Header{},
+	}
+}
+
+// getJSON decodes the response body into target. It returns false when the service answers 404.
+// Network errors and 5xx responses are retried up to maxRetries times, then reported as ErrServiceUnavailable.
+// Every request carries c.header. Cancelling ctx aborts the request in flight and the wait before a retry.
+func (c *jsonClient) getJSON(ctx context.Context, endpoint string, target interface{}) (bool, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			if err := wait(ctx, c.retryBackoff*time.Duration(attempt)); err != nil {
+				return false, fmt.Errorf("%s: %w, last attempt: %v", c.service, err, lastErr)
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return false, fmt.Errorf("%s: building request: %w", c.service, err)
+		}
+		req.Header = c.header.Clone()
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, fmt.Errorf("%s: request failed: %w", c.service, ctx.Err())
+			}
+			lastErr = fmt.Errorf("%s: request failed: %w", c.service, err)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("%s: reading response: %w", c.service, err)
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			return false, nil
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("%s: unexpected status %d", c.service, resp.StatusCode)
+			continue
+		case resp.StatusCode != http.StatusOK:
+			return false, fmt.Errorf("%s: unexpected status %d", c.service, resp.StatusCode)
+		}
+
+		if err := json.Unmarshal(body, target); err != nil {
+			return false, fmt.Errorf("%s: decoding response: %w", c.service, err)
+		}
+
+		return true, nil
+	}
+
+	return false, fmt.Errorf("%w: %w", ErrServiceUnavailable, lastErr)
+}
+
+// wait returns after d, or with the error of ctx when it is done first.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
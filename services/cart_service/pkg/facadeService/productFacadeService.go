@@ -1,46 +1,45 @@
 package facadeService
 
-import "github.com/google/uuid"
-
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/cart-service/pkg/money"
+	"github.com/google/uuid"
+)
+
+// Product is the catalog view of a product. MaxQuantity caps the units a cart may hold, 0 means no limit.
+// Type is "COURSE" for the courses of course_service, the product id being the course id. Other products are physical.
+// Stock is the units the catalog has, nil when it does not count them.
 type Product struct {
-	Id          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Price       float64   `json:"price"`
-	IsAvalaible bool      `json:"isAvalaible"`
-	Disccount   float64   `json:"disccount"`
+	Id          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Price       money.Money `json:"price"`
+	IsAvalaible bool        `json:"isAvalaible"`
+	Disccount   money.Money `json:"disccount"`
+	MaxQuantity int         `json:"maxQuantity"`
+	Stock       *int        `json:"stock,omitempty"`
 }
 
 type ProductFacadeService interface {
-	GetProductById(id uuid.UUID) (*Product, error)
-	GetProductsByIdIn(id []uuid.UUID) (*[]Product, error)
-}
-
-type ProductFacadeServiceImpl struct {
+	GetProductById(ctx context.Context, id uuid.UUID) (*Product, error)
+	GetProductsByIdIn(ctx context.Context, id []uuid.UUID) (*[]Product, error)
 }
 
-func NewProductFacadeService() ProductFacadeService {
-	return &ProductFacadeServiceImpl{}
-}
+var ErrProductNotFound = errors.New("product not found")
 
-// Return Generic until Prodcut Serive is implemented
-func (p *ProductFacadeServiceImpl) GetProductById(id uuid.UUID) (*Product, error) {
-	return p.getDummyProduct(), nil
+// ProductNotFoundError is returned when the catalog has no product for the requested id.
+// It matches ErrProductNotFound through errors.Is.
+type ProductNotFoundError struct {
+	ProductID uuid.UUID
 }
 
-func (p *ProductFacadeServiceImpl) GetProductsByIdIn(id []uuid.UUID) (*[]Product, error) {
-	prodcuts := make([]Product, len(id))
-	for range id {
-		prodcuts = append(prodcuts, *p.getDummyProduct())
-	}
-	return &prodcuts, nil
+func (e *ProductNotFoundError) Error() string {
+	return fmt.Sprintf("product not found: %s", e.ProductID)
 }
 
-func (p *ProductFacadeServiceImpl) getDummyProduct() *Product {
-	return &Product{
-		Id:          uuid.New(),
-		Name:        "Product Name",
-		Price:       100.00,
-		IsAvalaible: true,
-		Disccount:   10.00,
-	}
+func (e *ProductNotFoundError) Is(target error) bool {
+	return target == ErrProductNotFound
 }
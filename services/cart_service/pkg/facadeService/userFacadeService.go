@@ -0,0 +1,28 @@
+package facadeService
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Address mirrors the AddressDTO served by user_service.
+// Country is an ISO 3166-1 alpha-2 code, State the subdivision code (e.g. "NY", "ON", "JAL").
+type Address struct {
+	ID           uint      `json:"id"`
+	UserID       uuid.UUID `json:"user_id"`
+	AddressLine1 string    `json:"address_line_1"`
+	AddressLine2 *string   `json:"address_line_2,omitempty"`
+	City         string    `json:"city"`
+	State        string    `json:"state"`
+	PostalCode   string    `json:"postal_code"`
+	Country      string    `json:"country"`
+	IsDefault    bool      `json:"is_default"`
+}
+
+type UserFacadeService interface {
+	GetDefaultAddress(ctx context.Context, userID uuid.UUID) (*Address, error)
+}
+
+var ErrAddressNotFound = errors.New("default address not found")
@@ -0,0 +1,298 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArithmeticCurrencyMismatch(t *testing.T) {
+	usd := New(1000, "USD")
+	eur := New(500, "EUR")
+
+	tests := []struct {
+		name string
+		op   func() error
+	}{
+		{name: "Add", op: func() error { _, err := usd.Add(eur); return err }},
+		{name: "Sub", op: func() error { _, err := usd.Sub(eur); return err }},
+		{name: "Compare", op: func() error { _, err := usd.Compare(eur); return err }},
+		{name: "Min", op: func() error { _, err := usd.Min(eur); return err }},
+		{name: "Max", op: func() error { _, err := usd.Max(eur); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.op(); !errors.Is(err, ErrCurrencyMismatch) {
+				t.Errorf("got %v, want ErrCurrencyMismatch", err)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	sum, err := New(1000, "USD").Add(New(250, "usd"))
+	if err != nil || !sum.Equals(New(1250, "USD")) {
+		t.Errorf("Add: got %s, %v", sum, err)
+	}
+
+	// The zero value has no currency and takes the one of the other operand
+	difference, err := Money{}.Sub(New(250, "EUR"))
+	if err != nil || !difference.Equals(New(-250, "EUR")) {
+		t.Errorf("Sub from the zero value: got %s, %v", difference, err)
+	}
+
+	min, err := New(1000, "USD").Min(New(999, "USD"))
+	if err != nil || !min.Equals(New(999, "USD")) {
+		t.Errorf("Min: got %s, %v", min, err)
+	}
+}
+
+func TestValidateCurrency(t *testing.T) {
+	tests := []struct {
+		currency string
+		valid    bool
+	}{
+		{currency: "USD", valid: true},
+		{currency: "eur", valid: true},
+		{currency: "JPY", valid: true},
+		{currency: "", valid: false},
+		{currency: "US", valid: false},
+		{currency: "USDD", valid: false},
+		{currency: "XXY", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			err := ValidateCurrency(tt.currency)
+			if tt.valid && err != nil {
+				t.Errorf("got %v, want no error", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidCurrency) {
+				t.Errorf("got %v, want ErrInvalidCurrency", err)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     Money
+		wantErr  error
+	}{
+		{value: "19.99", currency: "USD", want: New(1999, "USD")},
+		{value: "19.9", currency: "usd", want: New(1990, "USD")},
+		{value: "19", currency: "USD", want: New(1900, "USD")},
+		{value: ".5", currency: "USD", want: New(50, "USD")},
+		{value: " +2.50 ", currency: "USD", want: New(250, "USD")},
+		{value: "-3.25", currency: "USD", want: New(-325, "USD")},
+		{value: "1500", currency: "JPY", want: New(1500, "JPY")},
+		{value: "1.234", currency: "KWD", want: New(1234, "KWD")},
+		{value: "19.994", currency: "USD", want: New(1999, "USD")},
+		{value: "19.995", currency: "USD", want: New(2000, "USD")},
+		{value: "-0.005", currency: "USD", want: New(-1, "USD")},
+		{value: "1.5", currency: "JPY", want: New(2, "JPY")},
+		{value: "", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: "-", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: ".", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: "--1", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: "1,50", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: "1.2.3", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: "1e3", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: "abc", currency: "USD", wantErr: ErrInvalidAmount},
+		{value: "99999999999999999999", currency: "USD", wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.currency, func(t *testing.T) {
+			got, err := Parse(tt.value, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Money
+		percent float64
+		want    Money
+	}{
+		{name: "exact", amount: New(1000, "USD"), percent: 12.5, want: New(125, "USD")},
+		{name: "rounds down", amount: New(1000, "USD"), percent: 33.33, want: New(333, "USD")},
+		{name: "rounds up", amount: New(1999, "USD"), percent: 10, want: New(200, "USD")},
+		{name: "half rounds away from zero", amount: New(1995, "USD"), percent: 10, want: New(200, "USD")},
+		{name: "negative half rounds away from zero", amount: New(-1995, "USD"), percent: 10, want: New(-200, "USD")},
+		{name: "percentage kept to two decimals", amount: New(10000, "USD"), percent: 10.004, want: New(1000, "USD")},
+		{name: "minor unit of the currency", amount: New(999, "JPY"), percent: 15, want: New(150, "JPY")},
+		{name: "zero", amount: New(1999, "USD"), percent: 0, want: New(0, "USD")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Percent(tt.percent); !got.Equals(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		rate     float64
+		currency string
+		want     Money
+	}{
+		{name: "same minor unit", amount: New(1000, "USD"), rate: 0.92, currency: "EUR", want: New(920, "EUR")},
+		{name: "half rounds away from zero", amount: New(1999, "USD"), rate: 17.5, currency: "mxn", want: New(34983, "MXN")},
+		{name: "negative half rounds away from zero", amount: New(-1999, "USD"), rate: 17.5, currency: "MXN", want: New(-34983, "MXN")},
+		{name: "to a currency without minor unit", amount: New(1000, "USD"), rate: 150.4, currency: "JPY", want: New(1504, "JPY")},
+		{name: "from a currency without minor unit", amount: New(1500, "JPY"), rate: 0.0067, currency: "USD", want: New(1005, "USD")},
+		{name: "to a currency with three decimals", amount: New(1000, "USD"), rate: 0.31, currency: "KWD", want: New(3100, "KWD")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Convert(tt.rate, tt.currency); !got.Equals(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		json  string
+	}{
+		{name: "cents", money: New(1999, "USD"), json: `{"amount":"19.99","currency":"USD"}`},
+		{name: "negative", money: New(-5, "EUR"), json: `{"amount":"-0.05","currency":"EUR"}`},
+		{name: "no minor unit", money: New(1500, "JPY"), json: `{"amount":"1500","currency":"JPY"}`},
+		{name: "three decimals", money: New(1234, "KWD"), json: `{"amount":"1.234","currency":"KWD"}`},
+		{name: "zero value", money: Money{}, json: `{"amount":"0.00","currency":""}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.money)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("got %s, want %s", data, tt.json)
+			}
+
+			var decoded Money
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !decoded.Equals(tt.money) {
+				t.Errorf("got %s back, want %s", decoded, tt.money)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    Money
+		fails   bool
+		wantErr error
+	}{
+		{name: "number amount", json: `{"amount":19.99,"currency":"EUR"}`, want: New(1999, "EUR")},
+		{name: "lower case currency", json: `{"amount":"5","currency":"eur"}`, want: New(500, "EUR")},
+		{name: "too many decimals", json: `{"amount":"19.995","currency":"USD"}`, want: New(2000, "USD")},
+		{name: "without currency", json: `{"amount":"5"}`, want: New(500, DefaultCurrency)},
+		{name: "bare number", json: `5.5`, want: New(550, DefaultCurrency)},
+		{name: "bare string", json: `"5.5"`, want: New(550, DefaultCurrency)},
+		{name: "null", json: `null`, want: Money{}},
+		{name: "unknown currency", fails: true, json: `{"amount":"5","currency":"ABC"}`, wantErr: ErrInvalidCurrency},
+		{name: "currency too long", fails: true, json: `{"amount":"5","currency":"USDD"}`, wantErr: ErrInvalidCurrency},
+		{name: "amount not a number", fails: true, json: `{"amount":"abc","currency":"USD"}`},
+		{name: "missing amount", fails: true, json: `{"currency":"USD"}`, wantErr: ErrInvalidAmount},
+		{name: "amount of the wrong type", fails: true, json: `{"amount":true,"currency":"USD"}`},
+		{name: "truncated object", fails: true, json: `{"amount":"5"`},
+		{name: "bare word", fails: true, json: `"abc"`, wantErr: ErrInvalidAmount},
+		{name: "array", fails: true, json: `[5]`, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := got.UnmarshalJSON([]byte(tt.json))
+
+			if !tt.fails {
+				if err != nil {
+					t.Fatalf("got error %v, want %s", err, tt.want)
+				}
+				if !got.Equals(tt.want) {
+					t.Errorf("got %s, want %s", got, tt.want)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("got %s, want an error", got)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestCopiesAreIdentical compares the package with the copies the other services carry.
+// It is skipped when the service is built on its own, without the rest of the repository.
+func TestCopiesAreIdentical(t *testing.T) {
+	own, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirs, err := filepath.Glob(filepath.Join(own, "..", "..", "..", "*", "pkg", "money"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	copies := 0
+	for _, dir := range dirs {
+		if dir == own {
+			continue
+		}
+		copies++
+
+		for _, file := range []string{"money.go", "money_test.go"} {
+			want, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(filepath.Join(dir, file))
+			if err != nil {
+				t.Errorf("copy in %s: %v", dir, err)
+				continue
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s differs from %s, keep the copies identical", filepath.Join(dir, file), file)
+			}
+		}
+	}
+
+	if copies == 0 {
+		t.Skip("no other service next to this one")
+	}
+}
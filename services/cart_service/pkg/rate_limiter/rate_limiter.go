@@ -38,7 +38,7 @@ func (rl *RateLimiter) Limit(c *fiber.Ctx) error {
 	count, err := rl.redisClient.Incr(ctx, key).Result()
 	if err != nil {
 		log.Printf("Error incrementing in Redis for %s: %v", key, err)
-		return c.Status(fiber.StatusInternalServerError).SendString("Internal server error")
+		return fiber.ErrInternalServerError
 	}
 
 	if count == 1 {
@@ -50,7 +50,7 @@ func (rl *RateLimiter) Limit(c *fiber.Ctx) error {
 
 	if count > int64(rl.rate) {
 		log.Printf("Rate limit exceeded for %s", ip)
-		return c.Status(fiber.StatusTooManyRequests).SendString("Too many requests")
+		return fiber.NewError(fiber.StatusTooManyRequests, "Too many requests")
 	}
 
 	return c.Next()
@@ -43,10 +43,15 @@ func GORMConfig() *gorm.DB {
 		&models.LessonModel{},
 		&models.ModuleModel{},
 		&models.ResourceModel{},
+		&models.EnrollmentModel{},
 	); err != nil {
 		log.Fatal("Failed to migrate database schema:", err)
 	}
 
+	if err := runMigrations(db); err != nil {
+		log.Fatal("Failed to migrate database data:", err)
+	}
+
 	log.Println("Database connected successfully!")
 	return db
 }
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/money"
+	"gorm.io/gorm"
+)
+
+// runMigrations applies the data migrations AutoMigrate can't express.
+// Each step checks the schema first, so running it again is a no-op.
+func runMigrations(db *gorm.DB) error {
+	return migrateCoursePrice(db)
+}
+
+// migrateCoursePrice moves the legacy DECIMAL courses.price column into the minor unit price_amount column.
+func migrateCoursePrice(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("courses", "price") {
+		return nil
+	}
+
+	factor := 1
+	for i := 0; i < money.MinorUnits(money.DefaultCurrency); i++ {
+		factor *= 10
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE `courses` SET `price_amount` = ROUND(`price` * ?)", factor).Error; err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn("courses", "price")
+	})
+	if err != nil {
+		return fmt.Errorf("migrating courses.price: %w", err)
+	}
+
+	log.Println("Migrated courses.price to price_amount")
+	return nil
+}
@@ -0,0 +1,175 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	customErrors "github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/application/errors"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/application/ports/input"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/shared/dtos"
+	logging "github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/log"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/rabbitmq"
+	"github.com/google/uuid"
+	"github.com/streadway/amqp"
+)
+
+// CoursePurchasesQueue receives the course purchases of cart_service, see dtos.CoursePurchaseEventDTO.
+// Its dead letter queue is rabbitmq.DeadLetterQueue(CoursePurchasesQueue).
+const CoursePurchasesQueue = "course_purchases_queue"
+
+const (
+	reconnectDelay = 5 * time.Second
+	maxAttempts    = 3
+	retryBackoff   = 2 * time.Second
+)
+
+var errInvalidPurchase = errors.New("course purchase: checkout_id, user_id and course_ids are required")
+
+// CoursePurchaseConsumer enrolls the buyers of courses. Messages are acknowledged once every course is enrolled.
+// Malformed purchases, unknown courses and purchases still failing after maxAttempts go to the dead letter queue.
+// Enrolling is idempotent, so a redelivered purchase is applied again safely.
+type CoursePurchaseConsumer struct {
+	conn    *amqp.Connection
+	useCase input.CourseUseCase
+}
+
+func NewCoursePurchaseConsumer(conn *amqp.Connection, useCase input.CourseUseCase) *CoursePurchaseConsumer {
+	return &CoursePurchaseConsumer{
+		conn:    conn,
+		useCase: useCase,
+	}
+}
+
+// Start consumes until ctx is done, opening a new channel when the current one fails.
+func (c *CoursePurchaseConsumer) Start(ctx context.Context) {
+	for {
+		if err := c.consume(ctx); err != nil {
+			logging.LogError("course_purchase", "consumer stopped", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (c *CoursePurchaseConsumer) consume(ctx context.Context) error {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := rabbitmq.DeclareQueueWithDeadLetter(ch, CoursePurchasesQueue); err != nil {
+		return err
+	}
+
+	if err := ch.Qos(1, 0, false); err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(
+		CoursePurchasesQueue,
+		"",
+		false, // Auto-Acknowledge
+		false, // Exclusive
+		false, // No-Local
+		false, // No-Wait
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return errors.New("channel closed")
+			}
+			c.handle(ctx, msg)
+		}
+	}
+}
+
+func (c *CoursePurchaseConsumer) handle(ctx context.Context, msg amqp.Delivery) {
+	var purchase dtos.CoursePurchaseEventDTO
+	if err := json.Unmarshal(msg.Body, &purchase); err != nil {
+		c.deadLetter(msg, err)
+		return
+	}
+
+	if err := c.apply(ctx, purchase); err != nil {
+		// Stopping is not a failure of the purchase, leave it for the next consumer
+		if ctx.Err() != nil {
+			msg.Nack(false, true)
+			return
+		}
+		c.deadLetter(msg, err)
+		return
+	}
+
+	if err := msg.Ack(false); err != nil {
+		logging.LogError("course_purchase", "can't acknowledge message", map[string]interface{}{
+			"checkout_id": purchase.CheckoutID,
+			"error":       err.Error(),
+		})
+		return
+	}
+
+	logging.LogSuccess("course_purchase", "Student successfully enrolled", map[string]interface{}{
+		"checkout_id": purchase.CheckoutID,
+		"student_id":  purchase.UserID,
+		"course_ids":  purchase.CourseIDs,
+	})
+}
+
+// apply enrolls the buyer in every course. Errors that may go away, like a lost database connection,
+// are retried with a growing backoff. An unknown course is not retried.
+func (c *CoursePurchaseConsumer) apply(ctx context.Context, purchase dtos.CoursePurchaseEventDTO) error {
+	if purchase.CheckoutID == uuid.Nil || purchase.UserID == uuid.Nil || len(purchase.CourseIDs) == 0 {
+		return errInvalidPurchase
+	}
+
+	for _, courseId := range purchase.CourseIDs {
+		var err error
+		for attempt := 1; attempt <= maxAttempts; attempt++ {
+			err = c.useCase.EnrollStudentInCourse(ctx, courseId, purchase.UserID, purchase.CheckoutID)
+			if err == nil || errors.Is(err, customErrors.ErrCourseNotFoundDB) || attempt == maxAttempts {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * retryBackoff):
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("enrolling in course %s: %w", courseId, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *CoursePurchaseConsumer) deadLetter(msg amqp.Delivery, reason error) {
+	logging.LogError("course_purchase", "moving message to "+rabbitmq.DeadLetterQueue(CoursePurchasesQueue), map[string]interface{}{
+		"message_id": msg.MessageId,
+		"error":      reason.Error(),
+	})
+	if err := msg.Nack(false, false); err != nil {
+		logging.LogError("course_purchase", "can't reject message", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
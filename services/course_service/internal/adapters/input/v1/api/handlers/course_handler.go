@@ -174,3 +174,51 @@ func (lh *CourseHandler) DeleteCourse(c *fiber.Ctx) error {
 
 	return response.OK(c, "Course successfully deleted", nil)
 }
+
+// GetEnrollmentStatus godoc
+// @Summary      Get the enrollment of a student
+// @Description  Tell whether a student is enrolled in a course.
+// @Tags         Courses
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string  true  "Course ID"
+// @Param        studentId  path      string  true  "Student ID"
+// @Success      200  {object}  response.ApiResponse{data=dtos.EnrollmentStatusDTO} "Enrollment successfully retrieved"
+// @Failure      400  {object}  response.ApiResponse "Bad Request"
+// @Failure      404  {object}  response.ApiResponse "Course not found"
+// @Router       /v1/api/courses/{id}/enrollments/{studentId} [get]
+func (lh *CourseHandler) GetEnrollmentStatus(c *fiber.Ctx) error {
+	logging.LogIncomingRequest(c, "get_enrollment_status")
+
+	id, err := utils.GetUUIDParam(c, "id")
+	if err != nil {
+		logging.LogError("get_enrollment_status", "invalid course ID", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return response.BadRequest(c, err.Error(), "invalid course ID")
+	}
+
+	studentId, err := utils.GetUUIDParam(c, "studentId")
+	if err != nil {
+		logging.LogError("get_enrollment_status", "invalid student ID", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return response.BadRequest(c, err.Error(), "invalid student ID")
+	}
+
+	enrolled, err := lh.useCase.IsStudentEnrolled(context.Background(), id, studentId)
+	if err != nil {
+		return response.HandleApplicationError(c, err, "get_enrollment_status", id.String())
+	}
+
+	logging.LogSuccess("get_enrollment_status", "Enrollment successfully retrieved", map[string]interface{}{
+		"course_id":  id,
+		"student_id": studentId,
+	})
+
+	return response.OK(c, "Enrollment Successfully Retrieved", dtos.EnrollmentStatusDTO{
+		CourseID:  id,
+		StudentID: studentId,
+		Enrolled:  enrolled,
+	})
+}
@@ -15,4 +15,5 @@ func CourseRoutes(app *fiber.App, courseHanlders handlers.CourseHandler) {
 	path.Post("", courseHanlders.CreateCourse)
 	path.Put("/:id", courseHanlders.UpdateCourse)
 	path.Delete("/:id", courseHanlders.DeleteCourse)
+	path.Get("/:id/enrollments/:studentId", courseHanlders.GetEnrollmentStatus)
 }
@@ -18,7 +18,8 @@ func (m *CourseMappers) DomainToModel(course domain.Course) models.CourseModel {
 		Category:        string(course.Category()),
 		Level:           string(course.Level()),
 		Slug:            course.Slug(),
-		Price:           course.Price(),
+		PriceAmount:     course.Price().Amount(),
+		Currency:        course.Price().Currency(),
 		IsFree:          course.IsFree(),
 		Rating:          float64(course.Rating()),
 		InstructorID:    course.InstructorID(),
@@ -0,0 +1,22 @@
+package mappers
+
+import (
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/output/models"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/domain"
+)
+
+type EnrollmentMappers struct{}
+
+func (m *EnrollmentMappers) DomainToModel(enrollment domain.Enrollment) models.EnrollmentModel {
+	return models.EnrollmentModel{
+		ID:         enrollment.ID(),
+		CourseID:   enrollment.CourseID(),
+		StudentID:  enrollment.StudentID(),
+		CheckoutID: enrollment.CheckoutID(),
+		EnrolledAt: enrollment.EnrolledAt(),
+	}
+}
+
+func (m *EnrollmentMappers) ModelToDomain(model models.EnrollmentModel) *domain.Enrollment {
+	return domain.NewEnrollmentFromModel(model.ID, model.CourseID, model.StudentID, model.CheckoutID, model.EnrolledAt)
+}
@@ -21,7 +21,8 @@ type CourseModel struct {
 	InstructorID    uuid.UUID     `gorm:"type:char(36);not null" json:"instructor_id"`
 	Modules         []ModuleModel `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"modules"`
 	Tags            StringArray   `gorm:"type:json" json:"tags"`
-	Price           float64       `gorm:"type:numeric(10,2)" json:"price"`
+	PriceAmount     int64         `gorm:"not null;default:0" json:"price_amount"`
+	Currency        string        `gorm:"type:char(3);not null;default:'USD'" json:"currency"`
 	IsFree          bool          `json:"is_free"`
 	IsPublished     bool          `json:"is_published"`
 	PublishedAt     *time.Time    `json:"published_at,omitempty"`
@@ -124,3 +125,16 @@ func (c *ResourceModel) BeforeCreate(tx *gorm.DB) (err error) {
 	c.UpdatedAt = time.Now()
 	return
 }
+
+// EnrollmentModel is unique per course and student, enrolling twice keeps the first enrollment.
+type EnrollmentModel struct {
+	ID         uuid.UUID `gorm:"type:char(36);primaryKey"`
+	CourseID   uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_course_student,priority:1"`
+	StudentID  uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_course_student,priority:2;index"`
+	CheckoutID uuid.UUID `gorm:"type:char(36);not null;index"`
+	EnrolledAt time.Time
+}
+
+func (EnrollmentModel) TableName() string {
+	return "course_enrollments"
+}
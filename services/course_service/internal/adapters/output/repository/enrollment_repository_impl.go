@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/output/mappers"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/output/models"
+	customErrors "github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/application/errors"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/application/ports/output"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EnrollmentRepositoryImpl struct {
+	db      gorm.DB
+	mappers mappers.EnrollmentMappers
+}
+
+func NewEnrollmentRepository(db gorm.DB) output.EnrollmentRepository {
+	return &EnrollmentRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create relies on the course and student unique index: a second enrollment affects no row,
+// so the enrollment count is only increased once per student.
+func (r *EnrollmentRepositoryImpl) Create(ctx context.Context, enrollment domain.Enrollment) (bool, error) {
+	enrollmentModel := r.mappers.DomainToModel(enrollment)
+
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&enrollmentModel)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		result = tx.Model(&models.CourseModel{}).
+			Where("id = ?", enrollment.CourseID()).
+			UpdateColumn("enrollment_count", gorm.Expr("enrollment_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return customErrors.ErrCourseNotFoundDB
+		}
+
+		created = true
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, customErrors.ErrCourseNotFoundDB) {
+			return false, err
+		}
+		return false, customErrors.NewDomainError("DATABASE_ERROR", "Error creating enrollment", err)
+	}
+
+	return created, nil
+}
+
+func (r *EnrollmentRepositoryImpl) Exists(ctx context.Context, courseId uuid.UUID, studentId uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.EnrollmentModel{}).
+		Where("course_id = ? AND student_id = ?", courseId, studentId).
+		Count(&count).Error
+	if err != nil {
+		return false, customErrors.NewDomainError("DATABASE_ERROR", "Error retrieving enrollment", err)
+	}
+
+	return count > 0, nil
+}
@@ -24,6 +24,8 @@ var (
 	ErrCourseNameRequired     = NewDomainError("COURSE_INVALID_INPUT", "Course domain: Course name is required", nil)
 	ErrCourseInvalidLanguage  = NewDomainError("COURSE_INVALID_LANGUAGE", "Course domain: The provided language is not valid", nil)
 	ErrCourseAlreadyPublished = NewDomainError("COURSE_ALREADY_PUBLISHED", "Course domain: The course has already been published", nil)
+	ErrCoursePriceInvalid     = NewDomainError("COURSE_INVALID_PRICE", "Course domain: The course price cannot be negative", nil)
+	ErrCourseCurrencyInvalid  = NewDomainError("COURSE_INVALID_CURRENCY", "Course domain: The course price currency must be an ISO 4217 code", nil)
 
 	ErrModuleNotFound           = NewDomainError("MODULE_NOT_FOUND", "Module domain: The module does not exist in the course", nil)
 	ErrModuleTitleInvalid       = NewDomainError("MODULE_INVALID_TITLE", "Module domain: The module title must be between 3 and 100 characters", nil)
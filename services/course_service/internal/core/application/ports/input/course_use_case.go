@@ -16,7 +16,8 @@ type CourseUseCase interface {
 	CreateCourse(ctx context.Context, dto dtos.CourseInsertDTO) (*dtos.CourseDTO, error)
 	UpdateCourse(ctx context.Context, id uuid.UUID, dto dtos.CourseInsertDTO) (*dtos.CourseDTO, error)
 	PublishCourse(ctx context.Context, id uuid.UUID) error
-	EnrollStudentInCourse(ctx context.Context, courseId uuid.UUID) error
+	EnrollStudentInCourse(ctx context.Context, courseId uuid.UUID, studentId uuid.UUID, checkoutId uuid.UUID) error
+	IsStudentEnrolled(ctx context.Context, courseId uuid.UUID, studentId uuid.UUID) (bool, error)
 	UpdateCourseRating(ctx context.Context, courseId uuid.UUID, rating float64) error
 	DeleteCourse(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,15 @@
+package output
+
+import (
+	"context"
+
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+type EnrollmentRepository interface {
+	// Create stores the enrollment and counts it on its course. It returns false when the student
+	// was already enrolled, in which case nothing changes.
+	Create(ctx context.Context, enrollment domain.Enrollment) (bool, error)
+	Exists(ctx context.Context, courseId uuid.UUID, studentId uuid.UUID) (bool, error)
+}
@@ -12,13 +12,15 @@ import (
 )
 
 type CourseUseCaseImpl struct {
-	courseRepository output.CourseRepository
-	mappers          mappers.CourseMappers
+	courseRepository     output.CourseRepository
+	enrollmentRepository output.EnrollmentRepository
+	mappers              mappers.CourseMappers
 }
 
-func NewCourseUseCase(courseRepository output.CourseRepository) input.CourseUseCase {
+func NewCourseUseCase(courseRepository output.CourseRepository, enrollmentRepository output.EnrollmentRepository) input.CourseUseCase {
 	return &CourseUseCaseImpl{
-		courseRepository: courseRepository,
+		courseRepository:     courseRepository,
+		enrollmentRepository: enrollmentRepository,
 	}
 }
 
@@ -104,19 +106,23 @@ func (us *CourseUseCaseImpl) PublishCourse(ctx context.Context, id uuid.UUID) er
 	return nil
 }
 
-func (us *CourseUseCaseImpl) EnrollStudentInCourse(ctx context.Context, courseId uuid.UUID) error {
-	course, err := us.courseRepository.GetById(ctx, courseId.String())
-	if err != nil {
+// EnrollStudentInCourse enrolls the student that bought the course in checkoutId.
+// Enrolling an already enrolled student does nothing, so a redelivered purchase is safe to apply again.
+func (us *CourseUseCaseImpl) EnrollStudentInCourse(ctx context.Context, courseId uuid.UUID, studentId uuid.UUID, checkoutId uuid.UUID) error {
+	enrollment := domain.NewEnrollment(courseId, studentId, checkoutId)
+	if _, err := us.enrollmentRepository.Create(ctx, *enrollment); err != nil {
 		return err
 	}
 
-	course.EnrollStudent()
+	return nil
+}
 
-	if _, err = us.courseRepository.Update(ctx, courseId, *course); err != nil {
-		return err
+func (us *CourseUseCaseImpl) IsStudentEnrolled(ctx context.Context, courseId uuid.UUID, studentId uuid.UUID) (bool, error) {
+	if _, err := us.courseRepository.GetById(ctx, courseId.String()); err != nil {
+		return false, err
 	}
 
-	return nil
+	return us.enrollmentRepository.Exists(ctx, courseId, studentId)
 }
 
 func (us *CourseUseCaseImpl) UpdateCourseRating(ctx context.Context, courseId uuid.UUID, rating float64) error {
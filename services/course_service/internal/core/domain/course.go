@@ -7,6 +7,7 @@ import (
 
 	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/output/models"
 	customErrors "github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/application/errors"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/money"
 	"github.com/google/uuid"
 )
 
@@ -40,7 +41,7 @@ type Course struct {
 	description     string
 	category        CourseCategory
 	level           CourseLevel
-	price           float64
+	price           money.Money
 	isFree          bool
 	rating          float64
 	instructorId    uuid.UUID
@@ -61,7 +62,7 @@ func (c *Course) Slug() string             { return c.slug }
 func (c *Course) Description() string      { return c.description }
 func (c *Course) Category() CourseCategory { return c.category }
 func (c *Course) Level() CourseLevel       { return c.level }
-func (c *Course) Price() float64           { return c.price }
+func (c *Course) Price() money.Money       { return c.price }
 func (c *Course) IsFree() bool             { return c.isFree }
 func (c *Course) InstructorID() uuid.UUID  { return c.instructorId }
 func (c *Course) ThumbnailURL() string     { return c.thumbnailURL }
@@ -80,7 +81,7 @@ func NewCourse(
 	description string,
 	category CourseCategory,
 	level CourseLevel,
-	price float64,
+	price money.Money,
 	isFree bool,
 	instructorId uuid.UUID,
 	thumbnailURL string,
@@ -92,6 +93,10 @@ func NewCourse(
 		return nil, customErrors.ErrCourseNameRequired
 	}
 
+	if err := validatePrice(price); err != nil {
+		return nil, err
+	}
+
 	c := &Course{
 		id:              uuid.New(),
 		name:            name,
@@ -129,7 +134,7 @@ func NewCourseFromModel(model models.CourseModel) *Course {
 		description:     model.Description,
 		category:        CourseCategory(model.Category),
 		level:           CourseLevel(model.Level),
-		price:           model.Price,
+		price:           money.New(model.PriceAmount, model.Currency),
 		isFree:          model.IsFree,
 		rating:          model.Rating,
 		slug:            model.Slug,
@@ -152,7 +157,7 @@ func (c *Course) UpdateInfo(
 	description string,
 	category CourseCategory,
 	level CourseLevel,
-	price float64,
+	price money.Money,
 	isFree bool,
 	thumbnailURL string,
 	language string,
@@ -162,6 +167,10 @@ func (c *Course) UpdateInfo(
 		return customErrors.ErrCourseNameRequired
 	}
 
+	if err := validatePrice(price); err != nil {
+		return err
+	}
+
 	c.name = name
 	c.description = description
 	c.category = category
@@ -182,6 +191,18 @@ func (c *Course) UpdateInfo(
 	return nil
 }
 
+// validatePrice rejects negative prices and currencies that are not ISO 4217 codes, the cart adds up course prices
+// with the prices of other products and cannot mix currencies.
+func validatePrice(price money.Money) error {
+	if price.IsNegative() {
+		return customErrors.ErrCoursePriceInvalid
+	}
+	if err := money.ValidateCurrency(price.Currency()); err != nil {
+		return customErrors.ErrCourseCurrencyInvalid
+	}
+	return nil
+}
+
 func (c *Course) generateSlug() {
 	slug := strings.ToLower(c.name)
 	slug = strings.ReplaceAll(slug, " ", "-")
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Enrollment gives a student access to a course. checkoutId is the cart checkout the course was bought in.
+type Enrollment struct {
+	id         uuid.UUID
+	courseId   uuid.UUID
+	studentId  uuid.UUID
+	checkoutId uuid.UUID
+	enrolledAt time.Time
+}
+
+func (e *Enrollment) ID() uuid.UUID         { return e.id }
+func (e *Enrollment) CourseID() uuid.UUID   { return e.courseId }
+func (e *Enrollment) StudentID() uuid.UUID  { return e.studentId }
+func (e *Enrollment) CheckoutID() uuid.UUID { return e.checkoutId }
+func (e *Enrollment) EnrolledAt() time.Time { return e.enrolledAt }
+
+func NewEnrollment(courseId uuid.UUID, studentId uuid.UUID, checkoutId uuid.UUID) *Enrollment {
+	return &Enrollment{
+		id:         uuid.New(),
+		courseId:   courseId,
+		studentId:  studentId,
+		checkoutId: checkoutId,
+		enrolledAt: time.Now(),
+	}
+}
+
+func NewEnrollmentFromModel(id uuid.UUID, courseId uuid.UUID, studentId uuid.UUID, checkoutId uuid.UUID, enrolledAt time.Time) *Enrollment {
+	return &Enrollment{
+		id:         id,
+		courseId:   courseId,
+		studentId:  studentId,
+		checkoutId: checkoutId,
+		enrolledAt: enrolledAt,
+	}
+}
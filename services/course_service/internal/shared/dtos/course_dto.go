@@ -3,12 +3,13 @@ package dtos
 import (
 	"time"
 
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/money"
 	"github.com/google/uuid"
 )
 
 // CourseInsertDTO represents the data required to create a new course.
 // @Description DTO used to insert a new course with necessary fields including title, description, level, and more.
-// @SchemaExample { "title": "Go Programming", "description": "Learn Go programming from scratch.", "thumbnail_url": "https://example.com/thumbnail.jpg", "level": "BEGINNER", "category": "PROGRAMMING", "language": "English", "instructor_id": "8c1d73a3-4a33-4c60-914f-76b91b3510ad", "tags": ["Go", "Programming"], "price": 50.0, "is_free": false }
+// @SchemaExample { "title": "Go Programming", "description": "Learn Go programming from scratch.", "thumbnail_url": "https://example.com/thumbnail.jpg", "level": "BEGINNER", "category": "PROGRAMMING", "language": "English", "instructor_id": "8c1d73a3-4a33-4c60-914f-76b91b3510ad", "tags": ["Go", "Programming"], "price": {"amount": "50.00", "currency": "USD"}, "is_free": false }
 type CourseInsertDTO struct {
 	// Title is the name of the course.
 	// @example Go Programming
@@ -42,9 +43,9 @@ type CourseInsertDTO struct {
 	// @example ["Go", "Programming"]
 	Tags []string `json:"tags"`
 
-	// Price is the cost of the course.
-	// @example 50.0
-	Price float64 `json:"price" validate:"gte=0"`
+	// Price is the cost of the course. A bare number is read in USD.
+	// @example {"amount": "50.00", "currency": "USD"}
+	Price money.Money `json:"price"`
 
 	// IsFree indicates whether the course is free or paid.
 	// @example false
@@ -53,7 +54,7 @@ type CourseInsertDTO struct {
 
 // CourseDTO represents a full course with details including instructor, pricing, and enrollment statistics.
 // @Description DTO that contains all the details of a course, including its modules, instructor, price, and more.
-// @SchemaExample { "id": "abc123", "title": "Go Programming", "slug": "go-programming", "description": "Learn Go programming from scratch.", "thumbnail_url": "https://example.com/thumbnail.jpg", "category": "PROGRAMMING", "level": "BEGINNER", "language": "English", "instructor_id": "8c1d73a3-4a33-4c60-914f-76b91b3510ad", "tags": ["Go", "Programming"], "price": 50.0, "is_free": false, "is_published": true, "enrollment_count": 100, "rating": 4.5, "review_count": 25, "published_at": "2025-03-12T10:00:00Z", "created_at": "2025-03-12T10:00:00Z", "updated_at": "2025-03-12T10:00:00Z", "modules": [...] }
+// @SchemaExample { "id": "abc123", "title": "Go Programming", "slug": "go-programming", "description": "Learn Go programming from scratch.", "thumbnail_url": "https://example.com/thumbnail.jpg", "category": "PROGRAMMING", "level": "BEGINNER", "language": "English", "instructor_id": "8c1d73a3-4a33-4c60-914f-76b91b3510ad", "tags": ["Go", "Programming"], "price": {"amount": "50.00", "currency": "USD"}, "is_free": false, "is_published": true, "enrollment_count": 100, "rating": 4.5, "review_count": 25, "published_at": "2025-03-12T10:00:00Z", "created_at": "2025-03-12T10:00:00Z", "updated_at": "2025-03-12T10:00:00Z", "modules": [...] }
 type CourseDTO struct {
 	// ID is the unique identifier for the course.
 	// @example abc123
@@ -96,8 +97,8 @@ type CourseDTO struct {
 	Tags []string `json:"tags"`
 
 	// Price is the cost of the course.
-	// @example 50.0
-	Price float64 `json:"price"`
+	// @example {"amount": "50.00", "currency": "USD"}
+	Price money.Money `json:"price"`
 
 	// IsFree indicates whether the course is free or paid.
 	// @example false
@@ -0,0 +1,33 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EnrollmentStatusDTO tells whether a student is enrolled in a course.
+// @Description DTO returned when checking the enrollment of a student in a course.
+// @SchemaExample { "course_id": "0d4f3a1e-4b0a-4a51-8a43-2f9e6a3f1c11", "student_id": "8c1d73a3-4a33-4c60-914f-76b91b3510ad", "enrolled": true }
+type EnrollmentStatusDTO struct {
+	// CourseID is the unique identifier of the course.
+	// @example 0d4f3a1e-4b0a-4a51-8a43-2f9e6a3f1c11
+	CourseID uuid.UUID `json:"course_id"`
+
+	// StudentID is the unique identifier of the student, the user id of user_service.
+	// @example 8c1d73a3-4a33-4c60-914f-76b91b3510ad
+	StudentID uuid.UUID `json:"student_id"`
+
+	// Enrolled indicates whether the student has access to the course.
+	// @example true
+	Enrolled bool `json:"enrolled"`
+}
+
+// CoursePurchaseEventDTO is published by cart_service once a checkout holding courses is bought.
+// The buyer is enrolled in every course of CourseIDs.
+type CoursePurchaseEventDTO struct {
+	CheckoutID  uuid.UUID   `json:"checkout_id"`
+	UserID      uuid.UUID   `json:"user_id"`
+	CourseIDs   []uuid.UUID `json:"course_ids"`
+	PurchasedAt time.Time   `json:"purchased_at"`
+}
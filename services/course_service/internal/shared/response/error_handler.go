@@ -17,6 +17,8 @@ func HandleApplicationError(c *fiber.Ctx, err error, action string, resourceID s
 		switch domainErr.Code {
 		case "COURSE_NOT_FOUND", "LESSON_NOT_FOUND", "MODULE_NOT_FOUND", "RESOURCE_NOT_FOUND":
 			return Error(c, fiber.StatusNotFound, domainErr.Message, domainErr.Code)
+		case "COURSE_INVALID_INPUT", "COURSE_INVALID_LANGUAGE", "COURSE_INVALID_PRICE", "COURSE_INVALID_CURRENCY":
+			return Error(c, fiber.StatusBadRequest, domainErr.Message, domainErr.Code)
 		case "DATABASE_ERROR":
 			return Error(c, fiber.StatusInternalServerError, domainErr.Message, domainErr.Code)
 		default:
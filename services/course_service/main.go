@@ -1,17 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"time"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/course-service/config"
 	_ "github.com/alexisTrejo11/ecommerce_microservice/course-service/docs"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/input/consumer"
 	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/input/v1/api/handlers"
 	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/input/v1/api/routes"
 	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/adapters/output/repository"
 	"github.com/alexisTrejo11/ecommerce_microservice/course-service/internal/core/application/usecase"
 	logging "github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/log"
+	"github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/rabbitmq"
 	ratelimiter "github.com/alexisTrejo11/ecommerce_microservice/course-service/pkg/rate_limter"
 	swagger "github.com/arsmn/fiber-swagger/v2"
 	"github.com/gofiber/fiber/v2"
@@ -40,12 +43,25 @@ func main() {
 	lessonRepository := repository.NewLessonRepository(*db)
 	moduleRepository := repository.NewModuleRepository(*db, lessonRepository)
 	courseRepository := repository.NewCourseRepository(*db, moduleRepository)
+	enrollmentRepository := repository.NewEnrollmentRepository(*db)
 
 	// Use Case
 	resourceUseCase := usecase.NewResourceUseCase(resourceRepository, lessonRepository)
 	lessonUseCase := usecase.NewLessonUseCase(lessonRepository, moduleRepository)
 	moduleUseCase := usecase.NewModuleUseCase(moduleRepository, courseRepository)
-	courseUseCase := usecase.NewCourseUseCase(courseRepository)
+	courseUseCase := usecase.NewCourseUseCase(courseRepository, enrollmentRepository)
+
+	// Course purchases from cart_service
+	rabbitConn, err := rabbitmq.ConnectRabbitMQ()
+	if err != nil {
+		log.Fatal("Failed to connect to RabbitMQ:", err)
+	}
+	defer rabbitConn.Close()
+
+	consumerCtx, stopConsumer := context.WithCancel(context.Background())
+	defer stopConsumer()
+	coursePurchaseConsumer := consumer.NewCoursePurchaseConsumer(rabbitConn, courseUseCase)
+	go coursePurchaseConsumer.Start(consumerCtx)
 
 	// Handler
 	lessonHandler := handlers.NewLessonHandler(lessonUseCase)
@@ -0,0 +1,332 @@
+// Package money is the amount value object shared by the cart and course services.
+// Both services carry a copy of this file and of its tests, TestCopiesAreIdentical keeps them identical.
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts that arrive without a currency code.
+const DefaultCurrency = "USD"
+
+var (
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	ErrInvalidAmount    = errors.New("money: invalid amount")
+	ErrInvalidCurrency  = errors.New("money: invalid currency")
+)
+
+// Active ISO 4217 currency codes.
+var isoCurrencies = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true, "AUD": true,
+	"AWG": true, "AZN": true, "BAM": true, "BBD": true, "BDT": true, "BGN": true, "BHD": true, "BIF": true,
+	"BMD": true, "BND": true, "BOB": true, "BRL": true, "BSD": true, "BTN": true, "BWP": true, "BYN": true,
+	"BZD": true, "CAD": true, "CDF": true, "CHF": true, "CLP": true, "CNY": true, "COP": true, "CRC": true,
+	"CUP": true, "CVE": true, "CZK": true, "DJF": true, "DKK": true, "DOP": true, "DZD": true, "EGP": true,
+	"ERN": true, "ETB": true, "EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true,
+	"GIP": true, "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true, "HTG": true,
+	"HUF": true, "IDR": true, "ILS": true, "INR": true, "IQD": true, "IRR": true, "ISK": true, "JMD": true,
+	"JOD": true, "JPY": true, "KES": true, "KGS": true, "KHR": true, "KMF": true, "KPW": true, "KRW": true,
+	"KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true, "LRD": true, "LSL": true,
+	"LYD": true, "MAD": true, "MDL": true, "MGA": true, "MKD": true, "MMK": true, "MNT": true, "MOP": true,
+	"MRU": true, "MUR": true, "MVR": true, "MWK": true, "MXN": true, "MYR": true, "MZN": true, "NAD": true,
+	"NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true, "OMR": true, "PAB": true, "PEN": true,
+	"PGK": true, "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true, "RON": true, "RSD": true,
+	"RUB": true, "RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true,
+	"SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true, "SVC": true, "SYP": true,
+	"SZL": true, "THB": true, "TJS": true, "TMT": true, "TND": true, "TOP": true, "TRY": true, "TTD": true,
+	"TWD": true, "TZS": true, "UAH": true, "UGX": true, "USD": true, "UYU": true, "UZS": true, "VES": true,
+	"VND": true, "VUV": true, "WST": true, "XAF": true, "XCD": true, "XOF": true, "XPF": true, "YER": true,
+	"ZAR": true, "ZMW": true, "ZWL": true,
+}
+
+// ISO 4217 currencies whose minor unit is not the usual cent.
+var minorUnitsByCurrency = map[string]int{
+	"BHD": 3, "CLP": 0, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "OMR": 3, "PYG": 0, "TND": 3, "UGX": 0, "VND": 0,
+}
+
+// Money is an amount expressed in the minor unit of an ISO 4217 currency (cents for USD).
+// The zero value has no currency and can be added to any Money.
+type Money struct {
+	amount   int64
+	currency string
+}
+
+func New(amount int64, currency string) Money {
+	return Money{amount: amount, currency: strings.ToUpper(currency)}
+}
+
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// FromFloat converts a major unit amount, rounding half away from zero.
+// Only use it at the edges, for data that still comes as float64.
+func FromFloat(value float64, currency string) Money {
+	factor := math.Pow10(MinorUnits(currency))
+	return New(int64(math.Round(value*factor)), currency)
+}
+
+// Parse reads a decimal major unit amount like "19.99" without going through float64.
+// Extra fraction digits are rounded half away from zero.
+func Parse(value string, currency string) (Money, error) {
+	number := strings.TrimSpace(value)
+	negative := strings.HasPrefix(number, "-")
+	if negative || strings.HasPrefix(number, "+") {
+		number = number[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(number, ".")
+	if whole == "" && fraction == "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+
+	digits := MinorUnits(currency)
+	roundUp := false
+	if len(fraction) > digits {
+		roundUp = fraction[digits] >= '5'
+		fraction = fraction[:digits]
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if roundUp {
+		amount++
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return New(amount, currency), nil
+}
+
+// ValidateCurrency fails with ErrInvalidCurrency unless currency is an active ISO 4217 code, in any case.
+func ValidateCurrency(currency string) error {
+	if !isoCurrencies[strings.ToUpper(currency)] {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	return nil
+}
+
+// MinorUnits returns the number of decimals of currency, 2 when unknown.
+func MinorUnits(currency string) int {
+	if digits, ok := minorUnitsByCurrency[strings.ToUpper(currency)]; ok {
+		return digits
+	}
+	return 2
+}
+
+func (m Money) Amount() int64    { return m.amount }
+func (m Money) Currency() string { return m.currency }
+func (m Money) IsZero() bool     { return m.amount == 0 }
+func (m Money) IsNegative() bool { return m.amount < 0 }
+func (m Money) IsPositive() bool { return m.amount > 0 }
+func (m Money) Negate() Money    { return Money{amount: -m.amount, currency: m.currency} }
+func (m Money) Multiply(n int64) Money {
+	return Money{amount: m.amount * n, currency: m.currency}
+}
+
+// Add fails with ErrCurrencyMismatch when both operands carry a different currency.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.matchCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount + other.amount, currency: currency}, nil
+}
+
+// Sub fails with ErrCurrencyMismatch like Add.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.matchCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount - other.amount, currency: currency}, nil
+}
+
+// Percent returns percent% of m rounded half away from zero to the minor unit.
+// Percentages are kept to two decimals (basis points).
+func (m Money) Percent(percent float64) Money {
+	basisPoints := int64(math.Round(percent * 100))
+	return Money{amount: divRound(m.amount*basisPoints, 10000), currency: m.currency}
+}
+
+// Convert returns m in currency at rate, the number of units of currency one unit of m is worth.
+// The result is rounded half away from zero to the minor unit of currency.
+func (m Money) Convert(rate float64, currency string) Money {
+	scale := math.Pow10(MinorUnits(currency) - MinorUnits(m.currency))
+	return Money{amount: int64(math.Round(float64(m.amount) * rate * scale)), currency: strings.ToUpper(currency)}
+}
+
+// Compare returns -1, 0 or 1. It fails with ErrCurrencyMismatch like Add.
+func (m Money) Compare(other Money) (int, error) {
+	if _, err := m.matchCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) Equals(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return m.currency == "" || other.currency == "" || m.currency == other.currency
+}
+
+func (m Money) Min(other Money) (Money, error) {
+	cmp, err := m.Compare(other)
+	if err != nil {
+		return Money{}, err
+	}
+	if cmp <= 0 {
+		return m, nil
+	}
+	return other, nil
+}
+
+func (m Money) Max(other Money) (Money, error) {
+	cmp, err := m.Compare(other)
+	if err != nil {
+		return Money{}, err
+	}
+	if cmp >= 0 {
+		return m, nil
+	}
+	return other, nil
+}
+
+// Float64 is meant for display and logging only.
+func (m Money) Float64() float64 {
+	return float64(m.amount) / math.Pow10(MinorUnits(m.currency))
+}
+
+// Decimal formats the amount in major units, e.g. "19.99".
+func (m Money) Decimal() string {
+	digits := MinorUnits(m.currency)
+	amount := m.amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	str := strconv.FormatInt(amount, 10)
+	if digits == 0 {
+		return sign + str
+	}
+	if len(str) <= digits {
+		str = strings.Repeat("0", digits-len(str)+1) + str
+	}
+	return sign + str[:len(str)-digits] + "." + str[len(str)-digits:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currency
+}
+
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.Decimal(), m.currency})
+}
+
+// UnmarshalJSON accepts {"amount": "19.99", "currency": "USD"}, where amount may also be a number.
+// A bare number or string is read in DefaultCurrency. A currency that is not an ISO 4217 code fails
+// with ErrInvalidCurrency, only the zero value, which has none, is written with an empty one.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	raw := moneyJSON{Currency: DefaultCurrency}
+	if len(data) > 0 && data[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(bytes.Trim(data, `"`), &raw.Amount); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAmount, data)
+	}
+
+	if raw.Currency != "" {
+		if err := ValidateCurrency(raw.Currency); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := Parse(raw.Amount.String(), raw.Currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func (m Money) matchCurrency(other Money) (string, error) {
+	if !m.SameCurrency(other) {
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	if m.currency == "" {
+		return other.currency, nil
+	}
+	return m.currency, nil
+}
+
+// divRound divides rounding half away from zero.
+func divRound(numerator, denominator int64) int64 {
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	if remainder < 0 {
+		remainder = -remainder
+	}
+	if remainder*2 >= denominator {
+		if numerator < 0 {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return quotient
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"strconv"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/adapters/input/http/v1/dto"
+	domainErrors "github.com/alexisTrejo11/ecommerce_microservice/internal/core/domain/errors"
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/core/ports/input"
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/shared/jwt"
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/shared/response"
@@ -13,17 +16,26 @@ import (
 	"github.com/google/uuid"
 )
 
+// ServiceTokenHeader carries the credential other services send to the internal routes.
+const ServiceTokenHeader = "X-Service-Token"
+
+const adminRole = "admin"
+
 type UserAddressHandler struct {
 	addressUseCase input.AddressUseCase
 	validator      *validator.Validate
 	jwtManager     jwt.JWTManager
+	serviceToken   string
 }
 
-func NewUserAddressHandler(addressUseCase input.AddressUseCase, jwtManager jwt.JWTManager) *UserAddressHandler {
+// NewUserAddressHandler takes the token the internal routes accept from other services,
+// when it is empty they only accept admin JWTs.
+func NewUserAddressHandler(addressUseCase input.AddressUseCase, jwtManager jwt.JWTManager, serviceToken string) *UserAddressHandler {
 	return &UserAddressHandler{
 		addressUseCase: addressUseCase,
 		jwtManager:     jwtManager,
 		validator:      validator.New(),
+		serviceToken:   serviceToken,
 	}
 }
 
@@ -52,6 +64,48 @@ func (uah *UserAddressHandler) MyAddresses(c *fiber.Ctx) error {
 	return response.OK(c, "Addresses retrieved successfully", addresses)
 }
 
+// DefaultAddress retrieves the default address of a user.
+// It is meant for other services (e.g. cart tax calculation), callers need the service token or an admin JWT.
+// @Summary Get a user's default address
+// @Description Retrieves the default address of the given user
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param X-Service-Token header string false "Service token"
+// @Success 200 {object} response.ApiResponse "Default address retrieved successfully"
+// @Failure 400 {object} response.ApiResponse "Bad request"
+// @Failure 401 {object} response.ApiResponse "Unauthorized"
+// @Failure 403 {object} response.ApiResponse "Forbidden"
+// @Failure 404 {object} response.ApiResponse "Not found"
+// @Router /v1/api/internal/users/{user_id}/address/default [get]
+func (uah *UserAddressHandler) DefaultAddress(c *fiber.Ctx) error {
+	if !uah.isServiceCall(c) {
+		claims, err := uah.jwtManager.ExtractAndValidateToken(c)
+		if err != nil {
+			return response.Unauthorized(c, "unauthorized", err.Error())
+		}
+		if claims.Role != adminRole {
+			return response.Forbidden(c, "forbidden", nil)
+		}
+	}
+
+	userId, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return response.BadRequest(c, "invalid user ID", err.Error())
+	}
+
+	address, err := uah.addressUseCase.GetDefaultAddress(context.Background(), userId)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrAddressNotFound) {
+			return response.NotFound(c, "default address not found", nil)
+		}
+		return response.InternalServerError(c, "internal server error", err.Error())
+	}
+
+	return response.OK(c, "Default address retrieved successfully", address)
+}
+
 // AddAddress adds a new address for the authenticated user.
 // @Summary Add user address
 // @Description Adds a new address for the authenticated user
@@ -166,3 +220,8 @@ func (uah *UserAddressHandler) DeleteAddress(c *fiber.Ctx) error {
 
 	return response.OK(c, "Address successfully deleted", nil)
 }
+
+func (uah *UserAddressHandler) isServiceCall(c *fiber.Ctx) bool {
+	token := c.Get(ServiceTokenHeader)
+	return uah.serviceToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(uah.serviceToken)) == 1
+}
@@ -29,6 +29,9 @@ func UserAddressRoutes(r fiber.Router, addresHandler *handlers.UserAddressHandle
 	addressPath.Post("", addresHandler.AddAddress)
 	addressPath.Put("/:id", addresHandler.UpdateMyAddress)
 	addressPath.Delete("/:id", addresHandler.DeleteAddress)
+
+	internalPath := r.Group("v1/api/internal/users")
+	internalPath.Get("/:user_id/address/default", addresHandler.DefaultAddress)
 }
 
 func UserMFARoutes(r fiber.Router, mfaHandler *handlers.UserMfaHandler) {
@@ -50,7 +50,14 @@ func (r *AddressRepository) FindAllByUserID(ctx context.Context, userID uuid.UUI
 }
 
 func (r *AddressRepository) FindDefaultByUserID(ctx context.Context, userID uuid.UUID) (*entities.Address, error) {
-	return nil, nil
+	var addressModel models.AddressModel
+	if err := r.db.First(&addressModel, "user_id = ? AND is_default = ?", userID.String(), true).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return r.addressMapper.ModelToDomain(&addressModel), nil
 }
 
 func (r *AddressRepository) SetDefault(ctx context.Context, id uint, userID uuid.UUID) error {
@@ -136,3 +136,8 @@ func GetEmailConfig() EmailConfig {
 		FromEmail:    os.Getenv("FROM_EMAIL"),
 	}
 }
+
+// GetInternalServiceToken is the credential other services send to the internal routes.
+func GetInternalServiceToken() string {
+	return os.Getenv("INTERNAL_SERVICE_TOKEN")
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/adapters/input/http/v1/dto"
+	repository "github.com/alexisTrejo11/ecommerce_microservice/internal/adapters/output"
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/adapters/output/persistence/mysql/mappers"
+	domainErrors "github.com/alexisTrejo11/ecommerce_microservice/internal/core/domain/errors"
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/core/ports/input"
 	"github.com/alexisTrejo11/ecommerce_microservice/internal/core/ports/output"
 	"github.com/google/uuid"
@@ -39,6 +41,18 @@ func (uc *AddressUseCasesImpl) GetUserAddresses(ctx context.Context, userID uuid
 	return addressDTOs, nil
 }
 
+func (uc *AddressUseCasesImpl) GetDefaultAddress(ctx context.Context, userID uuid.UUID) (*dto.AddressDTO, error) {
+	address, err := uc.addressRepository.FindDefaultByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domainErrors.ErrAddressNotFound
+		}
+		return nil, fmt.Errorf("error fetching default address: %w", err)
+	}
+
+	return uc.addressMappers.EntityToDTO(*address), nil
+}
+
 func (uc *AddressUseCasesImpl) AddAddress(ctx context.Context, addressDTO *dto.AddressInsertDTO) error {
 	address := uc.addressMappers.InsertDtoToEntity(*addressDTO)
 	address.UserID = addressDTO.UserID
@@ -11,4 +11,5 @@ var (
 	ErrForbbiden           = errors.New("not allowed to get this data")
 	ErrAccountBanned       = errors.New("account has been banned")
 	ErrAccountNotActivated = errors.New("account has not been activated")
+	ErrAddressNotFound     = errors.New("address not found")
 )
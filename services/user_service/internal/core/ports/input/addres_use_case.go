@@ -9,6 +9,7 @@ import (
 
 type AddressUseCase interface {
 	GetUserAddresses(ctx context.Context, userID uuid.UUID) ([]*dto.AddressDTO, error)
+	GetDefaultAddress(ctx context.Context, userID uuid.UUID) (*dto.AddressDTO, error)
 	AddAddress(ctx context.Context, address *dto.AddressInsertDTO) error
 	UpdateAddress(ctx context.Context, id uint, address *dto.AddressInsertDTO) error
 	DeleteAddress(ctx context.Context, id uint, userID uuid.UUID) error
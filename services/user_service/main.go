@@ -85,7 +85,7 @@ func main() {
 
 	// Handler
 	authHandler := handlers.NewAuthHandler(authUseCase, *jwtManager, emailUseCase)
-	userAddresHandler := handlers.NewUserAddressHandler(addresUseCase, *jwtManager)
+	userAddresHandler := handlers.NewUserAddressHandler(addresUseCase, *jwtManager, config.GetInternalServiceToken())
 	sessionHandler := handlers.NewSessionHandler(sessionUseCase, *jwtManager)
 	mfaHandler := handlers.NewUserMfaHandler(mfaUseCase, *jwtManager)
 